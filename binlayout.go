@@ -0,0 +1,101 @@
+package gosap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBinSublevels is the number of sublevels SAP allows below a warehouse.
+const MaxBinSublevels = 4
+
+// BinSublevelRange describes the codes used for one sublevel of a warehouse layout, e.g. the
+// aisles A1..A4. Codes takes precedence over the numeric range when set.
+type BinSublevelRange struct {
+	// Codes lists the sublevel codes explicitly.
+	Codes []string
+	// Prefix is prepended to every number of the From..To range.
+	Prefix string
+	From   int
+	To     int
+	// Width zero pads the numbers of the range, e.g. Width 2 gives 01, 02, ...
+	Width int
+}
+
+// BinLayout describes bins to create in a warehouse as the cartesian product of its sublevel
+// ranges, outermost sublevel first.
+type BinLayout struct {
+	Warehouse string
+	Sublevels []BinSublevelRange
+	// Template provides the remaining fields (quantities, attributes, ...) copied to every bin.
+	// Its AbsEntry, BinCode and sublevels are ignored, so a bin read from SAP can serve as one.
+	Template BinLocation
+}
+
+func (r BinSublevelRange) codes() ([]string, error) {
+	if len(r.Codes) > 0 {
+		return r.Codes, nil
+	}
+
+	if r.To < r.From {
+		return nil, fmt.Errorf("invalid sublevel range %d..%d", r.From, r.To)
+	}
+
+	codes := make([]string, 0, r.To-r.From+1)
+	for n := r.From; n <= r.To; n++ {
+		codes = append(codes, fmt.Sprintf("%s%0*d", r.Prefix, r.Width, n))
+	}
+
+	return codes, nil
+}
+
+// BinLocations expands the layout into the bin locations it describes, without creating them.
+func (l BinLayout) BinLocations() ([]BinLocation, error) {
+	if l.Warehouse == "" {
+		return nil, errors.New("bin layout has no warehouse")
+	}
+
+	if len(l.Sublevels) == 0 || len(l.Sublevels) > MaxBinSublevels {
+		return nil, fmt.Errorf("bin layout needs between 1 and %d sublevels, got %d",
+			MaxBinSublevels, len(l.Sublevels))
+	}
+
+	combinations := [][]string{{}}
+	for _, sublevel := range l.Sublevels {
+		codes, err := sublevel.codes()
+		if err != nil {
+			return nil, err
+		}
+
+		next := make([][]string, 0, len(combinations)*len(codes))
+		for _, prefix := range combinations {
+			for _, code := range codes {
+				combination := make([]string, len(prefix), len(prefix)+1)
+				copy(combination, prefix)
+				next = append(next, append(combination, code))
+			}
+		}
+
+		combinations = next
+	}
+
+	locations := make([]BinLocation, 0, len(combinations))
+	for _, combination := range combinations {
+		location := l.Template
+		location.Warehouse = l.Warehouse
+		location.AbsEntry, location.BinCode = 0, ""
+
+		// Sublevels of the template below the ones of the layout don't belong to these bins.
+		sublevels := []*string{&location.Sublevel1, &location.Sublevel2, &location.Sublevel3, &location.Sublevel4}
+		for i := range sublevels {
+			*sublevels[i] = ""
+		}
+
+		for i, code := range combination {
+			*sublevels[i] = code
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,124 @@
+package gosap_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinLayoutBinLocations(t *testing.T) {
+	t.Parallel()
+
+	layout := gosap.BinLayout{
+		Warehouse: "05",
+		Sublevels: []gosap.BinSublevelRange{
+			{Prefix: "A", From: 1, To: 2},
+			{Codes: []string{"E1", "E2"}},
+			{Prefix: "N", From: 1, To: 1, Width: 2},
+		},
+		Template: gosap.BinLocation{MaximumQty: "10"},
+	}
+
+	locations, err := layout.BinLocations()
+	require.NoError(t, err)
+	require.Len(t, locations, 4)
+
+	codes := make([][]string, 0, len(locations))
+	for _, location := range locations {
+		assert.Equal(t, "05", location.Warehouse)
+		assert.Equal(t, gosap.Decimal("10"), location.MaximumQty)
+		assert.Empty(t, location.Sublevel4)
+		codes = append(codes, []string{location.Sublevel1, location.Sublevel2, location.Sublevel3})
+	}
+
+	assert.Equal(t, [][]string{
+		{"A1", "E1", "N01"},
+		{"A1", "E2", "N01"},
+		{"A2", "E1", "N01"},
+		{"A2", "E2", "N01"},
+	}, codes)
+}
+
+func TestBinLayoutInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosap.BinLayout{Sublevels: []gosap.BinSublevelRange{{From: 1, To: 1}}}.BinLocations()
+	assert.Error(t, err)
+
+	_, err = gosap.BinLayout{Warehouse: "05"}.BinLocations()
+	assert.Error(t, err)
+
+	_, err = gosap.BinLayout{
+		Warehouse: "05",
+		Sublevels: []gosap.BinSublevelRange{{From: 3, To: 1}},
+	}.BinLocations()
+	assert.Error(t, err)
+}
+
+func TestBinLayoutIgnoresTemplateSublevels(t *testing.T) {
+	t.Parallel()
+
+	layout := gosap.BinLayout{
+		Warehouse: "05",
+		Sublevels: []gosap.BinSublevelRange{
+			{Prefix: "A", From: 1, To: 2},
+		},
+		Template: gosap.BinLocation{
+			AbsEntry: 12, BinCode: "05-X-E9-N99-L1",
+			Sublevel1: "X", Sublevel2: "E9", Sublevel3: "N99", Sublevel4: "L1", Attribute1: "Cold",
+		},
+	}
+
+	locations, err := layout.BinLocations()
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+
+	for i, location := range locations {
+		assert.Equal(t, []string{fmt.Sprintf("A%d", i+1), "", "", ""},
+			[]string{location.Sublevel1, location.Sublevel2, location.Sublevel3, location.Sublevel4})
+		assert.Equal(t, "Cold", location.Attribute1)
+		assert.Zero(t, location.AbsEntry)
+		assert.Empty(t, location.BinCode)
+	}
+}
+
+func TestCreateBinLocations(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	created, err := session.CreateBinLocations(cfg, gosap.BinLayout{
+		Warehouse: "05",
+		Sublevels: []gosap.BinSublevelRange{{Prefix: "A", From: 1, To: 2}, {Codes: []string{"E1"}}},
+		Template:  gosap.BinLocation{AbsEntry: 7, BinCode: "05-A9-E9", MaximumQty: "10"},
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.NotEqual(t, created[0].AbsEntry, created[1].AbsEntry)
+
+	var bodies []map[string]any
+
+	for _, r := range sent() {
+		if r.Method == http.MethodPost && r.Path == "/b1s/v1/BinLocations" {
+			bodies = append(bodies, r.Body)
+		}
+	}
+
+	assert.Equal(t, []map[string]any{
+		{"Warehouse": "05", "Sublevel1": "A1", "Sublevel2": "E1", "MaximumQty": float64(10)},
+		{"Warehouse": "05", "Sublevel1": "A2", "Sublevel2": "E1", "MaximumQty": float64(10)},
+	}, bodies)
+	assert.Len(t, server.Entities("BinLocations"), 2)
+}
@@ -0,0 +1,157 @@
+package gosap_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBusinessPartner(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	created, err := session.CreateBusinessPartner(cfg, gosap.BusinessPartner{
+		CardCode: "C20000",
+		CardName: "Maxi-Teq",
+		CardType: gosap.CardTypeCustomer,
+		BPAddresses: []gosap.BPAddress{
+			{AddressName: "Main", AddressType: gosap.AddressTypeBillTo, Street: "Rue de Marseille"},
+		},
+		ContactEmployees: []gosap.ContactEmployee{{Name: "Amel"}},
+	})
+	require.NoError(t, err)
+
+	body := lastRequest(t, sent(), http.MethodPost).Body
+	assert.Equal(t, "C20000", body["CardCode"])
+	assert.Equal(t, "cCustomer", body["CardType"])
+	assert.Equal(t, []any{map[string]any{
+		"AddressName": "Main", "AddressType": "bo_BillTo", "Street": "Rue de Marseille",
+	}}, body["BPAddresses"])
+	assert.NotContains(t, body, "GroupCode")
+
+	assert.Equal(t, "Maxi-Teq", created.CardName)
+	assert.Equal(t, gosap.CardTypeCustomer, created.CardType)
+	require.Len(t, created.BPAddresses, 1)
+	assert.Equal(t, gosap.AddressTypeBillTo, created.BPAddresses[0].AddressType)
+	require.Len(t, created.ContactEmployees, 1)
+	assert.Equal(t, "Amel", created.ContactEmployees[0].Name)
+
+	partner, err := session.GetBusinessPartner(cfg, "C20000")
+	require.NoError(t, err)
+	assert.Equal(t, created.CardName, partner.CardName)
+}
+
+func TestUpdateBusinessPartner(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("BusinessPartners", gosap.BusinessPartner{
+		CardCode: "C20000", CardName: "Maxi-Teq", CardType: gosap.CardTypeCustomer, Phone1: "71000000",
+	}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.UpdateBusinessPartner(cfg, "C20000", gosap.BusinessPartner{CardName: "Maxi-Teq SA"}))
+
+	patch := lastRequest(t, sent(), http.MethodPatch)
+	assert.Equal(t, "/b1s/v1/BusinessPartners('C20000')", patch.Path)
+	assert.Equal(t, map[string]any{"CardName": "Maxi-Teq SA"}, patch.Body)
+
+	partner, err := session.GetBusinessPartner(cfg, "C20000")
+	require.NoError(t, err)
+	assert.Equal(t, "Maxi-Teq SA", partner.CardName)
+	assert.Equal(t, "71000000", partner.Phone1)
+}
+
+func TestGetLeads(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("BusinessPartners",
+		gosap.BusinessPartner{CardCode: "C20000", CardName: "Maxi-Teq", CardType: gosap.CardTypeCustomer},
+		gosap.BusinessPartner{CardCode: "L10000", CardName: "Prospect", CardType: gosap.CardTypeLead},
+		gosap.BusinessPartner{CardCode: "V10000", CardName: "Acme", CardType: gosap.CardTypeSupplier},
+	))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	leads, err := session.GetLeads(cfg)
+	require.NoError(t, err)
+	require.Len(t, leads.Value, 1)
+	assert.Equal(t, "L10000", leads.Value[0].CardCode)
+	assert.Equal(t, "Prospect", leads.Value[0].CardName)
+
+	get := lastRequest(t, sent(), http.MethodGet)
+	assert.Equal(t, "/b1s/v1/BusinessPartners", get.Path)
+
+	leads, err = session.GetLeads(cfg, "CardCode", "CardName", "CardType", "Phone1")
+	require.NoError(t, err)
+	require.Len(t, leads.Value, 1)
+	assert.Equal(t, gosap.CardTypeLead, leads.Value[0].CardType)
+}
+
+func TestGetClientsPages(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	server.PageSize = 2
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, server.Seed("BusinessPartners",
+			gosap.BusinessPartner{CardCode: fmt.Sprintf("C%05d", i), CardType: gosap.CardTypeCustomer, CreditLimit: "100"},
+			gosap.BusinessPartner{CardCode: fmt.Sprintf("V%05d", i), CardType: gosap.CardTypeSupplier},
+		))
+	}
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	clients, err := session.GetClients(cfg, "CardCode", "CreditLimit")
+	require.NoError(t, err)
+	require.Len(t, clients.Value, 5)
+
+	for i, client := range clients.Value {
+		assert.Equal(t, fmt.Sprintf("C%05d", i+1), client.CardCode)
+		assert.Equal(t, gosap.Decimal("100"), client.CreditLimit)
+	}
+
+	pages := 0
+
+	for _, r := range sent() {
+		if r.Method == http.MethodGet && r.Path == "/b1s/v1/BusinessPartners" {
+			pages++
+		}
+	}
+
+	assert.Equal(t, 3, pages)
+}
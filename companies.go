@@ -0,0 +1,186 @@
+package gosap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// ErrUnknownCompany is returned, wrapped, for a company name missing from Companies.
+var ErrUnknownCompany = errors.New("unknown company")
+
+// Companies holds the configs of several company databases by name, with a session per
+// company authenticated on first use, so one process can work with all of them.
+type Companies struct {
+	mu       sync.Mutex
+	configs  map[string]Config
+	sessions map[string]*Session
+}
+
+// NewCompanies returns the registry of configs. Names are case-insensitive.
+func NewCompanies(configs map[string]Config) *Companies {
+	c := &Companies{configs: map[string]Config{}, sessions: map[string]*Session{}}
+
+	for name, cfg := range configs {
+		c.configs[strings.ToLower(name)] = cfg
+	}
+
+	return c
+}
+
+// LoadCompanies reads the configs of companies from file, in any format viper supports but
+// env. Keys at the top level are shared by all companies, the ones under a company override
+// them. The environment overrides both, prefixed with the company name, e.g. SPAIN_DB_PASSWORD:
+//
+//	IP: sap.local
+//	COMPANIES:
+//	  france:
+//	    COMPANY_DB: SBO_FR
+//	    DB_USERNAME: manager
+//	  spain:
+//	    COMPANY_DB: SBO_ES
+//	    TIME_ZONE: Europe/Madrid
+func LoadCompanies(file string) (*Companies, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("could not read companies due to %s", err)
+	}
+
+	shared := v.AllSettings()
+	delete(shared, "companies")
+
+	companies := v.GetStringMap("companies")
+	if len(companies) == 0 {
+		return nil, fmt.Errorf("no companies in %s", file)
+	}
+
+	configs := map[string]Config{}
+
+	for name, settings := range companies {
+		settings, ok := settings.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid settings of company %s", name)
+		}
+
+		cv := newViper()
+		cv.SetEnvPrefix(name)
+		cv.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+		cv.AutomaticEnv()
+
+		if err := cv.MergeConfigMap(shared); err != nil {
+			return nil, err
+		}
+
+		if err := cv.MergeConfigMap(settings); err != nil {
+			return nil, err
+		}
+
+		cfg, err := unmarshalConfig(cv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config of company %s due to %s", name, err)
+		}
+
+		configs[name] = cfg
+	}
+
+	return NewCompanies(configs), nil
+}
+
+// Names returns the names of the companies, sorted.
+func (c *Companies) Names() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.configs))
+	for name := range c.configs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Configure changes the config of every company, e.g. to set a Logger or Middleware. Sessions
+// already authenticated keep the config they logged in with.
+func (c *Companies) Configure(configure func(name string, cfg *Config)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, cfg := range c.configs {
+		configure(name, &cfg)
+		c.configs[name] = cfg
+	}
+}
+
+// Config returns the config of the company name.
+func (c *Companies) Config(name string) (Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg, ok := c.configs[strings.ToLower(name)]
+	if !ok {
+		return Config{}, fmt.Errorf("%w %s", ErrUnknownCompany, name)
+	}
+
+	return cfg, nil
+}
+
+// Session returns the session of the company name, sending its requests with ctx. It logs in
+// on first use, with a client of its own, and again once the session expired.
+func (c *Companies) Session(ctx context.Context, name string) (*Session, Config, error) {
+	name = strings.ToLower(name)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg, ok := c.configs[name]
+	if !ok {
+		return nil, Config{}, fmt.Errorf("%w %s", ErrUnknownCompany, name)
+	}
+
+	session, ok := c.sessions[name]
+	if !ok {
+		session, err := AuthenticateContext(ctx, cfg)
+		if err != nil {
+			return nil, Config{}, fmt.Errorf("could not log in to company %s due to %w", name, err)
+		}
+
+		c.sessions[name] = session
+
+		return session.WithContext(ctx), cfg, nil
+	}
+
+	if session.Expired() {
+		if err := session.WithContext(ctx).Relogin(cfg); err != nil {
+			return nil, Config{}, fmt.Errorf("could not log in to company %s due to %w", name, err)
+		}
+	}
+
+	return session.WithContext(ctx), cfg, nil
+}
+
+// Logout logs out the sessions of all companies.
+func (c *Companies) Logout() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+
+	for name, session := range c.sessions {
+		if err := session.Logout(c.configs[name]); err != nil && !session.Expired() {
+			errs = append(errs, fmt.Errorf("company %s: %w", name, err))
+		}
+
+		delete(c.sessions, name)
+	}
+
+	return errors.Join(errs...)
+}
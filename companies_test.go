@@ -0,0 +1,103 @@
+package gosap_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanies(t *testing.T) {
+	france := gosaptest.NewServer()
+	defer france.Close()
+
+	spain := gosaptest.NewServer()
+	defer spain.Close()
+
+	require.NoError(t, france.Seed("Items", gosap.Item{ItemCode: "A00001", ItemName: "Palette"}))
+	require.NoError(t, spain.Seed("Items", gosap.Item{ItemCode: "A00001", ItemName: "Palé"}))
+
+	franceCfg, spainCfg := france.Config(), spain.Config()
+
+	file := filepath.Join(t.TempDir(), "companies.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(`
+IP: %s
+COMPANY_DB: %s
+DB_USERNAME: %s
+DB_PASSWORD: wrong
+COMPANIES:
+  france:
+    PORT: %d
+  spain:
+    PORT: %d
+    TIME_ZONE: Europe/Madrid
+    PRICE_DECIMALS: 4
+`, franceCfg.IP, gosaptest.CompanyDB, gosaptest.Username, franceCfg.Port, spainCfg.Port)), 0o600))
+
+	t.Setenv("FRANCE_DB_PASSWORD", gosaptest.Password)
+	t.Setenv("SPAIN_DB_PASSWORD", gosaptest.Password)
+
+	companies, err := gosap.LoadCompanies(file)
+	require.NoError(t, err)
+	assert.Empty(t, viper.AllKeys())
+
+	assert.Equal(t, []string{"france", "spain"}, companies.Names())
+
+	cfg, err := companies.Config("Spain")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Madrid", cfg.TimeZone)
+	assert.Equal(t, spainCfg.Port, cfg.Port)
+	assert.EqualValues(t, 4, cfg.Precision.Prices)
+	assert.Equal(t, gosap.DefaultDecimalPrecision.Amounts, cfg.Precision.Amounts)
+
+	_, err = companies.Config("italy")
+	require.ErrorIs(t, err, gosap.ErrUnknownCompany)
+
+	names := map[string]string{}
+
+	for _, name := range companies.Names() {
+		session, cfg, err := companies.Session(context.Background(), name)
+		require.NoError(t, err)
+
+		item, err := session.GetItem(cfg, "A00001")
+		require.NoError(t, err)
+
+		names[name] = item.ItemName
+	}
+
+	assert.Equal(t, map[string]string{"france": "Palette", "spain": "Palé"}, names)
+
+	first, _, err := companies.Session(context.Background(), "france")
+	require.NoError(t, err)
+
+	again, _, err := companies.Session(context.Background(), "france")
+	require.NoError(t, err)
+	assert.Equal(t, first.B1Session, again.B1Session)
+
+	require.NoError(t, companies.Logout())
+	assert.Zero(t, france.Sessions())
+	assert.Zero(t, spain.Sessions())
+}
+
+func TestLoadConfigLeavesGlobalViper(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gosap.env"),
+		[]byte("IP=sap.local\nCOMPANY_DB=SBODEMO\nBASE_URL=https://proxy.local/erp/b1s\nAPI_VERSION=v2\n"), 0o600))
+
+	cfg, err := gosap.LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "sap.local", cfg.IP)
+	assert.Equal(t, uint16(gosap.B1DeaultPort), cfg.Port)
+	assert.Equal(t, "https://proxy.local/erp/b1s/v2", cfg.ServiceURL())
+
+	assert.Empty(t, viper.AllKeys())
+}
@@ -1,57 +1,179 @@
 package gosap
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net"
+	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const B1DeaultPort = 50000
 
+// APIVersion is a version of the Service Layer API.
+type APIVersion string
+
+const (
+	// APIv1 is the OData v3 API under /b1s/v1.
+	APIv1 APIVersion = "v1"
+	// APIv2 is the OData v4 API under /b1s/v2.
+	APIv2 APIVersion = "v2"
+)
+
 type Config struct {
 	IP        string `mapstructure:"IP"`
 	Port      uint16 `mapstructure:"PORT"`
 	CompanyDB string `mapstructure:"COMPANY_DB"`
 	Username  string `mapstructure:"DB_USERNAME"`
 	Password  string `mapstructure:"DB_PASSWORD"`
-}
-
+	// BaseURL is the root of the Service Layer, e.g. https://proxy.local/sap/b1s behind a
+	// reverse proxy. Empty uses https://IP:PORT/b1s.
+	BaseURL string `mapstructure:"BASE_URL"`
+	// Version is the Service Layer API to use, APIv1 when empty.
+	Version APIVersion `mapstructure:"API_VERSION"`
+	// TimeZone is the IANA name of the company time zone SAP dates and times are in.
+	TimeZone string `mapstructure:"TIME_ZONE"`
+	// Precision is the number of decimals the company keeps per kind of value.
+	Precision DecimalPrecision `mapstructure:",squash"`
+	// Transport sends the requests of sessions authenticated with this config, e.g. to record or
+	// replay them. Nil uses a transport that doesn't verify the Service Layer certificate.
+	Transport http.RoundTripper `mapstructure:"-"`
+	// Middleware wraps Transport for every request, the first one outermost.
+	Middleware []Middleware `mapstructure:"-"`
+	// Logger logs every request sent to the Service Layer. Nil disables logging.
+	Logger *slog.Logger `mapstructure:"-"`
+	// TracerProvider traces sessions authenticated with this config. Nil uses the global one,
+	// which records nothing unless the application installs a provider.
+	TracerProvider trace.TracerProvider `mapstructure:"-"`
+}
+
+// LoadConfig reads the config from gosap.env in path and the environment. It doesn't touch the
+// global viper instance, so it can be used next to other viper users.
 func LoadConfig(path string) (Config, error) {
-	viper.AddConfigPath(path)
-	viper.SetConfigName("gosap")
-	viper.SetConfigType("env")
+	v := newViper()
+	v.AddConfigPath(path)
+	v.SetConfigName("gosap")
+	v.SetConfigType("env")
 
-	viper.SetDefault("IP", "")
-	viper.SetDefault("PORT", B1DeaultPort)
-	viper.SetDefault("COMPANY_DB", "")
-	viper.SetDefault("USERNAME", "")
-	viper.SetDefault("PASSWORD", "")
-
-	viper.AutomaticEnv()
+	v.AutomaticEnv()
 
 	config := Config{}
 
-	err := viper.ReadInConfig()
+	err := v.ReadInConfig()
 	if err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 			return config, err
 		}
 	}
 
-	err = viper.Unmarshal(&config)
-	if err != nil {
+	return unmarshalConfig(v)
+}
+
+// newViper returns a viper instance with the defaults of the config.
+func newViper() *viper.Viper {
+	v := viper.New()
+
+	v.SetDefault("IP", "")
+	v.SetDefault("PORT", B1DeaultPort)
+	v.SetDefault("COMPANY_DB", "")
+	v.SetDefault("DB_USERNAME", "")
+	v.SetDefault("DB_PASSWORD", "")
+	v.SetDefault("BASE_URL", "")
+	v.SetDefault("API_VERSION", "")
+	v.SetDefault("TIME_ZONE", "")
+	v.SetDefault("AMOUNT_DECIMALS", DefaultDecimalPrecision.Amounts)
+	v.SetDefault("PRICE_DECIMALS", DefaultDecimalPrecision.Prices)
+	v.SetDefault("RATE_DECIMALS", DefaultDecimalPrecision.Rates)
+	v.SetDefault("QUANTITY_DECIMALS", DefaultDecimalPrecision.Quantities)
+	v.SetDefault("PERCENT_DECIMALS", DefaultDecimalPrecision.Percents)
+	v.SetDefault("MEASURE_DECIMALS", DefaultDecimalPrecision.Measures)
+
+	return v
+}
+
+func unmarshalConfig(v *viper.Viper) (Config, error) {
+	config := Config{}
+
+	if err := v.Unmarshal(&config); err != nil {
 		return config, err
 	}
 
+	if _, err := time.LoadLocation(config.TimeZone); err != nil {
+		return config, fmt.Errorf("invalid TIME_ZONE %q: %w", config.TimeZone, err)
+	}
+
+	switch config.Version {
+	case "", APIv1, APIv2:
+	default:
+		return config, fmt.Errorf("invalid API_VERSION %q", config.Version)
+	}
+
 	return config, nil
 }
 
+// Location returns the company time zone, UTC when none or an unknown one is configured.
+func (c *Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Decimals returns the configured precision, or DefaultDecimalPrecision when none is set.
+func (c *Config) Decimals() DecimalPrecision {
+	if c.Precision == (DecimalPrecision{}) {
+		return DefaultDecimalPrecision
+	}
+
+	return c.Precision
+}
+
+func (c *Config) httpClient() *http.Client {
+	client := defaultClient()
+	if c.Transport != nil {
+		client.Transport = c.Transport
+	}
+
+	if c.Logger != nil {
+		client.Transport = loggingMiddleware(c.Logger)(client.Transport)
+	}
+
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		client.Transport = c.Middleware[i](client.Transport)
+	}
+
+	return client
+}
+
+func defaultClient() *http.Client {
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	return &http.Client{Transport: tr}
+}
+
+func (c *Config) AdminInfoEndpoint() string {
+	return fmt.Sprintf("%s/CompanyService_GetAdminInfo", c.ServiceURL())
+}
+
 func (c *Config) LoginEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/Login", net.JoinHostPort(c.IP, strconv.Itoa(int(c.Port))))
+	return fmt.Sprintf("%s/Login", c.ServiceURL())
+}
+
+func (c *Config) LogoutEndpoint() string {
+	return fmt.Sprintf("%s/Logout", c.ServiceURL())
 }
 
 func (c *Config) LoginPayload() (string, error) {
@@ -67,88 +189,223 @@ func (c *Config) LoginPayload() (string, error) {
 	return string(res), nil
 }
 
-func (c *Config) GetItemsEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/Items?$select=ItemCode,ItemName,PurchaseUnitWidth",
-		c.hostPort())
+// DefaultItemFields is the projection used when items are fetched without explicit fields.
+var DefaultItemFields = []string{"ItemCode", "ItemName", "PurchaseUnitWidth"}
+
+// itemSelect builds the $select query option for items. "*" selects every field.
+func itemSelect(fields []string) string {
+	if len(fields) == 0 {
+		fields = DefaultItemFields
+	}
+
+	return "?$select=" + strings.Join(fields, ",")
+}
+
+// GetItemsEndpoint returns the items endpoint selecting fields, or DefaultItemFields when none
+// are given.
+func (c *Config) GetItemsEndpoint(fields ...string) string {
+	return fmt.Sprintf("%s/Items%s", c.ServiceURL(), itemSelect(fields))
+}
+
+// GetItemEndpoint returns the endpoint of a single item selecting fields, or
+// DefaultItemFields when none are given.
+func (c *Config) GetItemEndpoint(id string, fields ...string) string {
+	return fmt.Sprintf("%s/Items(%s)%s", c.ServiceURL(), quoteKey(id), itemSelect(fields))
+}
+
+func (c *Config) CreateItemEndpoint() string {
+	return fmt.Sprintf("%s/Items", c.ServiceURL())
+}
+
+func (c *Config) UpdateItemEndpoint(id string) string {
+	return fmt.Sprintf("%s/Items(%s)", c.ServiceURL(), quoteKey(id))
+}
+
+func (c *Config) CancelItemEndpoint(id string) string {
+	return fmt.Sprintf("%s/Items(%s)/Cancel", c.ServiceURL(), quoteKey(id))
+}
+
+// DefaultBusinessPartnerFields is the projection used when suppliers, clients or leads are
+// listed without explicit fields.
+var DefaultBusinessPartnerFields = []string{"CardCode", "CardName"}
+
+// businessPartnersOfType returns the endpoint listing the business partners of the card type
+// code, e.g. 'S', with fields, or DefaultBusinessPartnerFields when none are given. "*" selects
+// every field.
+func (c *Config) businessPartnersOfType(code string, fields []string) string {
+	if len(fields) == 0 {
+		fields = DefaultBusinessPartnerFields
+	}
+
+	return fmt.Sprintf("%s/BusinessPartners?$select=%s&$filter=%s",
+		c.ServiceURL(), strings.Join(fields, ","), escapeQuery("CardType eq "+quoteKey(code)))
+}
+
+func (c *Config) GetSuppliersEndpoint(fields ...string) string {
+	return c.businessPartnersOfType("S", fields)
+}
+
+func (c *Config) GetClientsEndpoint(fields ...string) string {
+	return c.businessPartnersOfType("C", fields)
+}
+
+func (c *Config) GetLeadsEndpoint(fields ...string) string {
+	return c.businessPartnersOfType("L", fields)
 }
 
-func (c *Config) GetItemEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/Items('%s')?$select=ItemCode,ItemName,PurchaseUnitWidth",
-		c.hostPort(), id)
+func (c *Config) GetBusinessPartnersEndpoint() string {
+	return fmt.Sprintf("%s/BusinessPartners", c.ServiceURL())
 }
 
-func (c *Config) GetSuppliersEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/BusinessPartners?$select=CardCode,CardName&$filter=CardType eq 'S'",
-		c.hostPort())
+func (c *Config) GetBusinessPartnerEndpoint(cardCode string) string {
+	return fmt.Sprintf("%s/BusinessPartners(%s)", c.ServiceURL(), quoteKey(cardCode))
 }
 
-func (c *Config) GetClientsEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/BusinessPartners?$select=CardCode,CardName&$filter=CardType eq 'C'",
-		c.hostPort())
+func (c *Config) GetPriceListsEndpoint() string {
+	return fmt.Sprintf("%s/PriceLists", c.ServiceURL())
+}
+
+func (c *Config) GetPriceListEndpoint(id int) string {
+	return fmt.Sprintf("%s/PriceLists(%d)", c.ServiceURL(), id)
+}
+
+func (c *Config) GetSpecialPricesEndpoint() string {
+	return fmt.Sprintf("%s/SpecialPrices", c.ServiceURL())
+}
+
+func (c *Config) GetSpecialPriceEndpoint(itemCode, cardCode string) string {
+	return fmt.Sprintf("%s/SpecialPrices(ItemCode=%s,CardCode=%s)",
+		c.ServiceURL(), quoteKey(itemCode), quoteKey(cardCode))
+}
+
+// GetItemSpecialPricesEndpoint returns the special prices of an item for any of cardCodes.
+func (c *Config) GetItemSpecialPricesEndpoint(itemCode string, cardCodes ...string) string {
+	filters := make([]string, 0, len(cardCodes))
+	for _, cardCode := range cardCodes {
+		filters = append(filters, "CardCode eq "+quoteKey(cardCode))
+	}
+
+	filter := "ItemCode eq " + quoteKey(itemCode)
+	if len(filters) > 0 {
+		filter += " and (" + strings.Join(filters, " or ") + ")"
+	}
+
+	return fmt.Sprintf("%s/SpecialPrices?$filter=%s", c.ServiceURL(), escapeQuery(filter))
 }
 
 func (c *Config) GetDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/DeliveryNotes(%s)", c.hostPort(), id)
+	return fmt.Sprintf("%s/DeliveryNotes(%s)", c.ServiceURL(), id)
 }
 
 func (c *Config) CloseDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/DeliveryNotes(%s)/Close", c.hostPort(), id)
+	return fmt.Sprintf("%s/DeliveryNotes(%s)/Close", c.ServiceURL(), id)
 }
 
 func (c *Config) ReopenDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/DeliveryNotes(%s)/Reopen", c.hostPort(), id)
+	return fmt.Sprintf("%s/DeliveryNotes(%s)/Reopen", c.ServiceURL(), id)
 }
 
 func (c *Config) CancelDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/DeliveryNotes(%s)/Cancel", c.hostPort(), id)
+	return fmt.Sprintf("%s/DeliveryNotes(%s)/Cancel", c.ServiceURL(), id)
 }
 
 func (c *Config) GetDeliveryNotesEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/DeliveryNotes", c.hostPort())
+	return fmt.Sprintf("%s/DeliveryNotes", c.ServiceURL())
 }
 
+// BuildEndpoint returns the URL of a link returned by the Service Layer, such as a nextLink:
+// /b1s/v1/Items?$skip=20 in v1, Items?$skip=20 relative to the service root in v2. Links under
+// /b1s/ are moved under BaseURL, other links are resolved against the service root like a
+// browser would, so they keep the scheme and host of BaseURL when it is set.
 func (c *Config) BuildEndpoint(endpoint string) string {
-	return fmt.Sprintf("https://%s%s", c.hostPort(), endpoint)
+	if strings.HasPrefix(endpoint, "/b1s/") {
+		return c.baseURL() + strings.TrimPrefix(endpoint, "/b1s")
+	}
+
+	root, err := url.Parse(c.ServiceURL() + "/")
+	if err != nil {
+		return fmt.Sprintf("%s/%s", c.ServiceURL(), endpoint)
+	}
+
+	link, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Sprintf("%s/%s", c.ServiceURL(), endpoint)
+	}
+
+	return root.ResolveReference(link).String()
 }
 
 func (c *Config) GetPurchaseOrdersEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseOrders", c.hostPort())
+	return fmt.Sprintf("%s/PurchaseOrders", c.ServiceURL())
 }
 
 func (c *Config) GetPurchaseOrderEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseOrders(%s)", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseOrders(%s)", c.ServiceURL(), id)
 }
 
 func (c *Config) ClosePurchaseOrderEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseOrders(%s)/Close", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseOrders(%s)/Close", c.ServiceURL(), id)
 }
 
 func (c *Config) CancelPurchaseOrderEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseOrders(%s)/Cancel", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseOrders(%s)/Cancel", c.ServiceURL(), id)
 }
 
 func (c *Config) ReopenPurchaseOrderEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseOrders(%s)/Reopen", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseOrders(%s)/Reopen", c.ServiceURL(), id)
 }
 
 func (c *Config) GetPurchaseDeliveryNotesEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseDeliveryNotes", c.hostPort())
+	return fmt.Sprintf("%s/PurchaseDeliveryNotes", c.ServiceURL())
 }
 
 func (c *Config) GetPurchaseDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseDeliveryNotes(%s)", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseDeliveryNotes(%s)", c.ServiceURL(), id)
 }
 
 func (c *Config) ClosePurchaseDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseDeliveryNotes(%s)/Close", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseDeliveryNotes(%s)/Close", c.ServiceURL(), id)
 }
 
 func (c *Config) CancelPurchaseDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseDeliveryNotes(%s)/Cancel", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseDeliveryNotes(%s)/Cancel", c.ServiceURL(), id)
 }
 
 func (c *Config) ReopenPurchaseDeliveryNoteEndpoint(id string) string {
-	return fmt.Sprintf("https://%s/b1s/v1/PurchaseDeliveryNotes(%s)/Reopen", c.hostPort(), id)
+	return fmt.Sprintf("%s/PurchaseDeliveryNotes(%s)/Reopen", c.ServiceURL(), id)
+}
+
+// quoteKey renders a string key as an OData literal, doubling embedded single quotes.
+func quoteKey(key string) string {
+	return "'" + strings.ReplaceAll(key, "'", "''") + "'"
+}
+
+// escapeQuery escapes a query option such as a $filter, encoding spaces as %20 like the
+// Service Layer documents rather than as +.
+func escapeQuery(option string) string {
+	return strings.ReplaceAll(url.QueryEscape(option), "+", "%20")
+}
+
+// ServiceURL returns the root of the Service Layer API endpoints are built on, e.g.
+// https://sap.local:50000/b1s/v1.
+func (c *Config) ServiceURL() string {
+	return c.baseURL() + "/" + string(c.apiVersion())
+}
+
+func (c *Config) baseURL() string {
+	if c.BaseURL != "" {
+		return strings.TrimSuffix(c.BaseURL, "/")
+	}
+
+	return fmt.Sprintf("https://%s/b1s", c.hostPort())
+}
+
+func (c *Config) apiVersion() APIVersion {
+	if c.Version == "" {
+		return APIv1
+	}
+
+	return c.Version
 }
 
 func (c *Config) hostPort() string {
@@ -156,37 +413,151 @@ func (c *Config) hostPort() string {
 }
 
 func (c *Config) GetInventoryCountingEndpoint(id int) string {
-	return fmt.Sprintf("https://%s/b1s/v1/InventoryCountings(%d)", c.hostPort(), id)
+	return fmt.Sprintf("%s/InventoryCountings(%d)", c.ServiceURL(), id)
 }
 
 func (c *Config) GetInventoryCountingsEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/InventoryCountings", c.hostPort())
+	return fmt.Sprintf("%s/InventoryCountings", c.ServiceURL())
 }
 
 func (c *Config) CreateInventoryCountingEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/InventoryCountings", c.hostPort())
+	return fmt.Sprintf("%s/InventoryCountings", c.ServiceURL())
 }
 
 func (c *Config) CloseInventoryCountingEndpoint(id int) string {
-	return fmt.Sprintf("https://%s/b1s/v1/InventoryCountings(%d)/Close", c.hostPort(), id)
+	return fmt.Sprintf("%s/InventoryCountings(%d)/Close", c.ServiceURL(), id)
 }
 
 func (c *Config) GetBinLocationEndpoint(id int) string {
-	return fmt.Sprintf("https://%s/b1s/v1/BinLocations(%d)", c.hostPort(), id)
+	return fmt.Sprintf("%s/BinLocations(%d)", c.ServiceURL(), id)
 }
 
 func (c *Config) GetBinLocationsEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/BinLocations", c.hostPort())
+	return fmt.Sprintf("%s/BinLocations", c.ServiceURL())
 }
 
 func (c *Config) CreateBinLocationEndpoint() string {
-	return fmt.Sprintf("https://%s/b1s/v1/BinLocations", c.hostPort())
+	return fmt.Sprintf("%s/BinLocations", c.ServiceURL())
 }
 
 func (c *Config) UpdateBinLocationEndpoint(id int) string {
-	return fmt.Sprintf("https://%s/b1s/v1/BinLocations(%d)", c.hostPort(), id)
+	return fmt.Sprintf("%s/BinLocations(%d)", c.ServiceURL(), id)
 }
 
 func (c *Config) DeleteBinLocationEndpoint(id int) string {
-	return fmt.Sprintf("https://%s/b1s/v1/BinLocations(%d)", c.hostPort(), id)
+	return fmt.Sprintf("%s/BinLocations(%d)", c.ServiceURL(), id)
+}
+
+func (c *Config) GetBinLocationFieldsEndpoint() string {
+	return fmt.Sprintf("%s/BinLocationFields", c.ServiceURL())
+}
+
+func (c *Config) GetBinLocationFieldEndpoint(id int) string {
+	return fmt.Sprintf("%s/BinLocationFields(%d)", c.ServiceURL(), id)
+}
+
+func (c *Config) GetBinLocationAttributesEndpoint() string {
+	return fmt.Sprintf("%s/BinLocationAttributes", c.ServiceURL())
+}
+
+func (c *Config) GetBinLocationAttributeEndpoint(id int) string {
+	return fmt.Sprintf("%s/BinLocationAttributes(%d)", c.ServiceURL(), id)
+}
+
+func (c *Config) GetDraftsEndpoint() string {
+	return fmt.Sprintf("%s/Drafts", c.ServiceURL())
+}
+
+func (c *Config) GetDraftEndpoint(id int) string {
+	return fmt.Sprintf("%s/Drafts(%d)", c.ServiceURL(), id)
+}
+
+func (c *Config) SaveDraftToDocumentEndpoint() string {
+	return fmt.Sprintf("%s/DraftsService_SaveDraftToDocument", c.ServiceURL())
+}
+
+func (c *Config) GetApprovalRequestsEndpoint() string {
+	return fmt.Sprintf("%s/ApprovalRequests", c.ServiceURL())
+}
+
+func (c *Config) GetPendingApprovalRequestsEndpoint() string {
+	return fmt.Sprintf("%s/ApprovalRequests?$filter=%s", c.ServiceURL(),
+		escapeQuery("Status eq "+ApprovalRequestPending.Literal()))
+}
+
+func (c *Config) GetApprovalRequestEndpoint(code int) string {
+	return fmt.Sprintf("%s/ApprovalRequests(%d)", c.ServiceURL(), code)
+}
+
+func (c *Config) GetDocumentsEndpoint(kind DocumentKind) string {
+	return fmt.Sprintf("%s/%s", c.ServiceURL(), kind.EntitySet)
+}
+
+func (c *Config) GetDocumentEndpoint(kind DocumentKind, id int) string {
+	return fmt.Sprintf("%s/%s(%d)", c.ServiceURL(), kind.EntitySet, id)
+}
+
+func (c *Config) GetUserTablesEndpoint() string {
+	return fmt.Sprintf("%s/UserTablesMD", c.ServiceURL())
+}
+
+func (c *Config) GetUserTableEndpoint(table string) string {
+	return fmt.Sprintf("%s/UserTablesMD(%s)", c.ServiceURL(), quoteKey(userTableName(table)))
+}
+
+func (c *Config) GetUserFieldsEndpoint() string {
+	return fmt.Sprintf("%s/UserFieldsMD", c.ServiceURL())
+}
+
+// GetTableUserFieldsEndpoint returns the user-defined fields of a system or user table.
+func (c *Config) GetTableUserFieldsEndpoint(table string) string {
+	return fmt.Sprintf("%s/UserFieldsMD?$filter=%s", c.ServiceURL(),
+		escapeQuery("TableName eq "+quoteKey(table)))
+}
+
+func (c *Config) GetUserFieldEndpoint(table string, fieldID int) string {
+	return fmt.Sprintf("%s/UserFieldsMD(TableName=%s,FieldID=%d)",
+		c.ServiceURL(), quoteKey(table), fieldID)
+}
+
+func (c *Config) GetUserObjectsEndpoint() string {
+	return fmt.Sprintf("%s/UserObjectsMD", c.ServiceURL())
+}
+
+func (c *Config) GetUserObjectEndpoint(code string) string {
+	return fmt.Sprintf("%s/UserObjectsMD(%s)", c.ServiceURL(), quoteKey(code))
+}
+
+// GetUserTableRowsEndpoint returns the entity set of a user-defined table without object.
+func (c *Config) GetUserTableRowsEndpoint(table string) string {
+	return fmt.Sprintf("%s/U_%s", c.ServiceURL(), userTableName(table))
+}
+
+func (c *Config) GetUserTableRowEndpoint(table, code string) string {
+	return fmt.Sprintf("%s/U_%s(%s)", c.ServiceURL(), userTableName(table), quoteKey(code))
+}
+
+// GetUserObjectRecordsEndpoint returns the entity set of a user-defined object.
+func (c *Config) GetUserObjectRecordsEndpoint(udo string) string {
+	return fmt.Sprintf("%s/%s", c.ServiceURL(), udo)
+}
+
+// GetUserObjectRecordEndpoint returns a record of a user-defined object by key, its Code for
+// master data objects or its DocEntry for document objects.
+func (c *Config) GetUserObjectRecordEndpoint(udo string, key any) string {
+	return fmt.Sprintf("%s/%s(%s)", c.ServiceURL(), udo, keyLiteral(key))
+}
+
+// keyLiteral renders an entity key as an OData literal.
+func keyLiteral(key any) string {
+	if s, ok := key.(string); ok {
+		return quoteKey(s)
+	}
+
+	return fmt.Sprint(key)
+}
+
+// userTableName strips the @ SAP puts in front of user table names.
+func userTableName(table string) string {
+	return strings.TrimPrefix(table, "@")
 }
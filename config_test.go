@@ -0,0 +1,90 @@
+package gosap_test
+
+import (
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBusinessPartnerEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cfg := gosap.Config{IP: "sap.local", Port: gosap.B1DeaultPort}
+
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/BusinessPartners('C20000')",
+		cfg.GetBusinessPartnerEndpoint("C20000"))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/BusinessPartners('O''NEIL')",
+		cfg.GetBusinessPartnerEndpoint("O'NEIL"))
+}
+
+func TestGetItemEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cfg := gosap.Config{IP: "sap.local", Port: gosap.B1DeaultPort}
+
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/Items('A00001')?$select=ItemCode,ItemName,PurchaseUnitWidth",
+		cfg.GetItemEndpoint("A00001"))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/Items('A00001')?$select=ItemCode,ItemPrices",
+		cfg.GetItemEndpoint("A00001", "ItemCode", "ItemPrices"))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/Items?$select=*", cfg.GetItemsEndpoint("*"))
+}
+
+func TestUserTableEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := gosap.Config{IP: "sap.local", Port: gosap.B1DeaultPort}
+
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/U_SCANS", cfg.GetUserTableRowsEndpoint("@SCANS"))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/U_SCANS('001')", cfg.GetUserTableRowEndpoint("SCANS", "001"))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/TRUCKS('T1')", cfg.GetUserObjectRecordEndpoint("TRUCKS", "T1"))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/LOADS(12)", cfg.GetUserObjectRecordEndpoint("LOADS", 12))
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/UserFieldsMD(TableName='@SCANS',FieldID=0)",
+		cfg.GetUserFieldEndpoint("@SCANS", 0))
+}
+
+func TestFilterEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cfg := gosap.Config{IP: "sap.local", Port: gosap.B1DeaultPort}
+
+	assert.Equal(t,
+		"https://sap.local:50000/b1s/v1/BusinessPartners?$select=CardCode,CardName&$filter=CardType%20eq%20%27S%27",
+		cfg.GetSuppliersEndpoint())
+	assert.Equal(t,
+		"https://sap.local:50000/b1s/v1/BusinessPartners?$select=CardCode,CardName&$filter=CardType%20eq%20%27C%27",
+		cfg.GetClientsEndpoint())
+	assert.Equal(t,
+		"https://sap.local:50000/b1s/v1/BusinessPartners?$select=CardCode,CardName&$filter=CardType%20eq%20%27L%27",
+		cfg.GetLeadsEndpoint())
+	assert.Equal(t,
+		"https://sap.local:50000/b1s/v1/BusinessPartners?$select=CardCode,Phone1&$filter=CardType%20eq%20%27C%27",
+		cfg.GetClientsEndpoint("CardCode", "Phone1"))
+	assert.NotContains(t, cfg.GetPendingApprovalRequestsEndpoint(), "+")
+	assert.Contains(t, cfg.GetPendingApprovalRequestsEndpoint(), "$filter=Status%20eq%20")
+}
+
+func TestServiceURL(t *testing.T) {
+	t.Parallel()
+
+	cfg := gosap.Config{IP: "sap.local", Port: gosap.B1DeaultPort}
+
+	assert.Equal(t, "https://sap.local:50000/b1s/v1", cfg.ServiceURL())
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/Login", cfg.LoginEndpoint())
+	assert.Equal(t, "https://sap.local:50000/b1s/v1/Items?$skip=20", cfg.BuildEndpoint("/b1s/v1/Items?$skip=20"))
+
+	cfg.BaseURL = "https://proxy.local/erp/b1s/"
+	cfg.Version = gosap.APIv2
+
+	assert.Equal(t, "https://proxy.local/erp/b1s/v2", cfg.ServiceURL())
+	assert.Equal(t, "https://proxy.local/erp/b1s/v2/PurchaseOrders(12)", cfg.GetPurchaseOrderEndpoint("12"))
+	assert.Equal(t, "https://proxy.local/erp/b1s/v2/Items?$skip=20", cfg.BuildEndpoint("Items?$skip=20"))
+	assert.Equal(t, "https://proxy.local/erp/b1s/v1/Items?$skip=20", cfg.BuildEndpoint("/b1s/v1/Items?$skip=20"))
+	assert.Equal(t, "https://other.local/b1s/v2/Items", cfg.BuildEndpoint("https://other.local/b1s/v2/Items"))
+	assert.Equal(t, "https://proxy.local/sl/Items?$skip=20", cfg.BuildEndpoint("/sl/Items?$skip=20"))
+	assert.Equal(t, "https://proxy.local/erp/b1s/v2/Items('A00001')", cfg.BuildEndpoint("Items('A00001')"))
+
+	cfg.BaseURL = "http://localhost:8080/b1s"
+
+	assert.Equal(t, "http://localhost:8080/sl/Items", cfg.BuildEndpoint("/sl/Items"))
+}
@@ -0,0 +1,152 @@
+package gosap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DocumentKind describes a marketing document entity set and the object type SAP uses to
+// reference it from other documents.
+type DocumentKind struct {
+	EntitySet  string
+	ObjectType int
+	ObjectCode DocumentObjectCode
+}
+
+var (
+	KindQuotation            = DocumentKind{"Quotations", 23, ObjectQuotations}
+	KindOrder                = DocumentKind{"Orders", 17, ObjectOrders}
+	KindDeliveryNote         = DocumentKind{"DeliveryNotes", 15, ObjectDeliveryNotes}
+	KindReturn               = DocumentKind{"Returns", 16, ObjectReturns}
+	KindInvoice              = DocumentKind{"Invoices", 13, ObjectInvoices}
+	KindPurchaseRequest      = DocumentKind{"PurchaseRequests", 1470000113, ObjectPurchaseRequest}
+	KindPurchaseQuotation    = DocumentKind{"PurchaseQuotations", 540000006, ObjectPurchaseQuotations}
+	KindPurchaseOrder        = DocumentKind{"PurchaseOrders", 22, ObjectPurchaseOrders}
+	KindPurchaseDeliveryNote = DocumentKind{"PurchaseDeliveryNotes", 20, ObjectPurchaseDeliveryNotes}
+	KindPurchaseReturn       = DocumentKind{"PurchaseReturns", 21, ObjectPurchaseReturns}
+	KindPurchaseInvoice      = DocumentKind{"PurchaseInvoices", 18, ObjectPurchaseInvoices}
+)
+
+// CopyRequest asks for a target document built from the open lines of a base document.
+type CopyRequest struct {
+	From     DocumentKind
+	DocEntry int
+	To       DocumentKind
+	// Quantities overrides the copied quantity per base LineNum. Lines without an override
+	// are copied with their remaining open quantity and a quantity of 0 leaves the line out.
+	Quantities map[int]Decimal
+}
+
+// OpenQuantityError reports a base line that can't supply the requested quantity.
+type OpenQuantityError struct {
+	LineNum   int
+	Requested Decimal
+	Open      Decimal
+}
+
+func (e *OpenQuantityError) Error() string {
+	return fmt.Sprintf("line %d: requested quantity %v exceeds open quantity %v", e.LineNum, e.Requested, e.Open)
+}
+
+// BuildCopy builds the target document of req from base, referencing every copied line
+// through BaseType, BaseEntry and BaseLine. All quantity violations are reported together.
+func BuildCopy(base Document, req CopyRequest) (Document, error) {
+	target := Document{DocumentHeader: DocumentHeader{CardCode: base.CardCode}}
+
+	var errs []error
+
+	seen := map[int]bool{}
+	for _, line := range base.DocumentLines {
+		seen[line.LineNum] = true
+
+		quantity, overridden := req.Quantities[line.LineNum]
+		if !overridden {
+			quantity = line.RemainingOpenQuantity
+		}
+
+		if err := quantity.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line.LineNum, err))
+
+			continue
+		}
+
+		if quantity.IsZero() {
+			continue
+		}
+
+		open := line.RemainingOpenQuantity
+		if err := open.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: open quantity: %w", line.LineNum, err))
+
+			continue
+		}
+		if !line.IsLineOpen() {
+			open = Zero
+		}
+
+		if quantity.Sign() < 0 || quantity.Cmp(open) > 0 {
+			errs = append(errs, &OpenQuantityError{LineNum: line.LineNum, Requested: quantity, Open: open})
+
+			continue
+		}
+
+		baseLine := line.LineNum
+		target.DocumentLines = append(target.DocumentLines, DocumentLine{
+			LineNum:   len(target.DocumentLines),
+			ItemCode:  line.ItemCode,
+			Quantity:  quantity,
+			BaseType:  req.From.ObjectType,
+			BaseEntry: base.DocEntry,
+			BaseLine:  &baseLine,
+		})
+	}
+
+	unknown := make([]int, 0)
+	for lineNum := range req.Quantities {
+		if !seen[lineNum] {
+			unknown = append(unknown, lineNum)
+		}
+	}
+
+	sort.Ints(unknown)
+
+	for _, lineNum := range unknown {
+		errs = append(errs, fmt.Errorf("line %d does not exist on %s(%d)", lineNum, req.From.EntitySet, base.DocEntry))
+	}
+
+	if len(errs) > 0 {
+		return target, errors.Join(errs...)
+	}
+
+	if len(target.DocumentLines) == 0 {
+		return target, fmt.Errorf("%s(%d) has no open quantity to copy", req.From.EntitySet, base.DocEntry)
+	}
+
+	return target, nil
+}
+
+// CopyDocument fetches the base document of req, builds the target document with BuildCopy
+// and posts it. The created document is returned.
+func (s *Session) CopyDocument(cfg Config, req CopyRequest) (_ *Document, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	base, err := retrieveDocument[Document](s, cfg.GetDocumentEndpoint(req.From, req.DocEntry))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch %s(%d) due to %w", req.From.EntitySet, req.DocEntry, err)
+	}
+
+	target, err := BuildCopy(*base, req)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := createDocument[Document](s, cfg.GetDocumentsEndpoint(req.To), target)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s from %s(%d) due to %w",
+			req.To.EntitySet, req.From.EntitySet, req.DocEntry, err)
+	}
+
+	return created, nil
+}
@@ -0,0 +1,162 @@
+package gosap_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func purchaseOrderToCopy() gosap.Document {
+	return gosap.Document{
+		DocumentHeader: gosap.DocumentHeader{DocEntry: 42, CardCode: "V10000"},
+		DocumentLines: []gosap.DocumentLine{
+			{LineNum: 0, ItemCode: "A00001", Quantity: "10", RemainingOpenQuantity: "4", LineStatus: gosap.BoStatusOpen},
+			{LineNum: 1, ItemCode: "A00002", Quantity: "5", RemainingOpenQuantity: "0", LineStatus: gosap.BoStatusClose},
+			{LineNum: 2, ItemCode: "A00003", Quantity: "8", RemainingOpenQuantity: "8", LineStatus: gosap.BoStatusOpen},
+		},
+	}
+}
+
+func TestBuildCopy(t *testing.T) {
+	t.Parallel()
+
+	req := gosap.CopyRequest{
+		From:       gosap.KindPurchaseOrder,
+		DocEntry:   42,
+		To:         gosap.KindPurchaseDeliveryNote,
+		Quantities: map[int]gosap.Decimal{2: "3"},
+	}
+
+	target, err := gosap.BuildCopy(purchaseOrderToCopy(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "V10000", target.CardCode)
+	require.Len(t, target.DocumentLines, 2)
+
+	for i, want := range []struct {
+		baseLine int
+		quantity gosap.Decimal
+	}{{0, "4"}, {2, "3"}} {
+		line := target.DocumentLines[i]
+		assert.Equal(t, i, line.LineNum)
+		assert.Equal(t, 22, line.BaseType)
+		assert.Equal(t, 42, line.BaseEntry)
+		require.NotNil(t, line.BaseLine)
+		assert.Equal(t, want.baseLine, *line.BaseLine)
+		assert.Equal(t, want.quantity, line.Quantity)
+	}
+}
+
+func TestBuildCopyExceedsOpenQuantity(t *testing.T) {
+	t.Parallel()
+
+	req := gosap.CopyRequest{
+		From:       gosap.KindPurchaseOrder,
+		To:         gosap.KindPurchaseDeliveryNote,
+		Quantities: map[int]gosap.Decimal{0: "5", 1: "1", 7: "1"},
+	}
+
+	_, err := gosap.BuildCopy(purchaseOrderToCopy(), req)
+	require.Error(t, err)
+
+	var openErr *gosap.OpenQuantityError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, 0, openErr.LineNum)
+	assert.Contains(t, err.Error(), "line 1")
+	assert.Contains(t, err.Error(), "line 7 does not exist")
+}
+
+func TestBuildCopyInvalidQuantity(t *testing.T) {
+	t.Parallel()
+
+	req := gosap.CopyRequest{
+		From:       gosap.KindPurchaseOrder,
+		To:         gosap.KindPurchaseDeliveryNote,
+		Quantities: map[int]gosap.Decimal{0: "two"},
+	}
+
+	_, err := gosap.BuildCopy(purchaseOrderToCopy(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 0")
+}
+
+func TestCopyDocument(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("PurchaseOrders", gosap.Document{
+		DocumentHeader: gosap.DocumentHeader{DocEntry: 42, CardCode: "V10000"},
+		DocumentLines: []gosap.DocumentLine{
+			{LineNum: 0, ItemCode: "A00001", Quantity: "10"},
+			{LineNum: 3, ItemCode: "A00002", Quantity: "5"},
+		},
+	}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	order, err := session.GetPurchaseOrder(cfg, "42")
+	require.NoError(t, err)
+	require.Len(t, order.DocumentLines, 2)
+
+	created, err := session.CopyDocument(cfg, gosap.CopyRequest{
+		From:       gosap.KindPurchaseOrder,
+		DocEntry:   42,
+		To:         gosap.KindPurchaseDeliveryNote,
+		Quantities: map[int]gosap.Decimal{order.DocumentLines[1].LineNum: "2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "V10000", created.CardCode)
+
+	post := lastRequest(t, sent(), http.MethodPost)
+	assert.Equal(t, "/b1s/v1/PurchaseDeliveryNotes", post.Path)
+
+	lines, ok := post.Body["DocumentLines"].([]any)
+	require.True(t, ok)
+	require.Len(t, lines, 2)
+
+	for i, want := range []struct {
+		baseLine int
+		quantity float64
+	}{
+		{order.DocumentLines[0].LineNum, 10},
+		{order.DocumentLines[1].LineNum, 2},
+	} {
+		line, ok := lines[i].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, float64(22), line["BaseType"], "line %d", i)
+		assert.Equal(t, float64(42), line["BaseEntry"], "line %d", i)
+		assert.Equal(t, float64(want.baseLine), line["BaseLine"], "line %d", i)
+		assert.Equal(t, want.quantity, line["Quantity"], "line %d", i)
+	}
+
+	assert.Len(t, server.Entities("PurchaseDeliveryNotes"), 1)
+}
+
+func TestCopyDocumentMissingBase(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	_, err = session.CopyDocument(cfg, gosap.CopyRequest{
+		From: gosap.KindPurchaseOrder, DocEntry: 99, To: gosap.KindPurchaseDeliveryNote,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not fetch PurchaseOrders(99)")
+	assert.Empty(t, server.Entities("PurchaseDeliveryNotes"))
+}
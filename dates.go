@@ -0,0 +1,202 @@
+package gosap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the date formats the Service Layer uses, the first one being written.
+var dateLayouts = []string{
+	time.DateOnly,
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+}
+
+// timeLayouts are the time of day formats the Service Layer uses, the first one being written.
+var timeLayouts = []string{
+	time.TimeOnly,
+	"15:04",
+	"1504",
+}
+
+// Date is a calendar date as used by the Service Layer for DocDate, ShipDate, ... It holds
+// midnight UTC of that day; use At to place it in the company time zone.
+//
+// Dates are read from "2024-05-01" or "2024-05-01T00:00:00Z" and written as "2024-05-01".
+// Empty strings give the zero Date, which is written back as an empty string.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the given day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the calendar date of t in its own location.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// ParseDate reads a date in any of the Service Layer formats.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateOf(t), nil
+		}
+	}
+
+	return Date{}, fmt.Errorf("invalid date %q", s)
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.Format(dateLayouts[0])
+}
+
+// Literal renders the date for use in a $filter expression, e.g. DocDate ge '2024-05-01'.
+func (d Date) Literal() string {
+	return "'" + d.String() + "'"
+}
+
+// At returns the instant of the date at the time of day t in loc. A nil loc means UTC.
+func (d Date) At(t TimeOfDay, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour, t.Minute, t.Second, 0, loc)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date %s", data)
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// TimeOfDay is a time without date as used by the Service Layer for DocTime, CountTime, ...
+// It is read from "14:30:00", "14:30", "1430" or the OData duration "PT14H30M" and written as
+// "14:30:00".
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+	// set tells an explicit midnight apart from an empty value.
+	set bool
+}
+
+// NewTimeOfDay returns the given time of day.
+func NewTimeOfDay(hour, minute, second int) TimeOfDay {
+	return TimeOfDay{Hour: hour, Minute: minute, Second: second, set: true}
+}
+
+// TimeOfDayOf returns the time of day of t in its own location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return NewTimeOfDay(t.Clock())
+}
+
+// ParseTimeOfDay reads a time of day in any of the Service Layer formats.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if s == "" {
+		return TimeOfDay{}, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return TimeOfDayOf(t), nil
+		}
+	}
+
+	if duration, ok := strings.CutPrefix(s, "PT"); ok {
+		d, err := time.ParseDuration(strings.ToLower(duration))
+		if err == nil && d >= 0 && d < 24*time.Hour {
+			return TimeOfDayOf(time.Time{}.Add(d)), nil
+		}
+	}
+
+	return TimeOfDay{}, fmt.Errorf("invalid time %q", s)
+}
+
+// IsZero reports whether t holds no time at all. Midnight is not zero.
+func (t TimeOfDay) IsZero() bool {
+	return !t.set && t.Hour == 0 && t.Minute == 0 && t.Second == 0
+}
+
+func (t TimeOfDay) String() string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = TimeOfDay{}
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid time %s", data)
+	}
+
+	parsed, err := ParseTimeOfDay(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+// CombineDateTime joins separate date and time fields, e.g. CountDate and CountTime, into one
+// instant in the company time zone of cfg. A nil time means midnight.
+func CombineDateTime(cfg Config, date *Date, clock *TimeOfDay) time.Time {
+	if date == nil || date.IsZero() {
+		return time.Time{}
+	}
+
+	var t TimeOfDay
+	if clock != nil {
+		t = *clock
+	}
+
+	return date.At(t, cfg.Location())
+}
@@ -0,0 +1,74 @@
+package gosap_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{`"2024-05-01"`, `"2024-05-01T00:00:00Z"`, `"2024-05-01T00:00:00"`, `"2024-05-01T00:00:00.000Z"`} {
+		var d gosap.Date
+		require.NoError(t, json.Unmarshal([]byte(in), &d), in)
+		assert.Equal(t, gosap.NewDate(2024, time.May, 1), d, in)
+
+		out, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.Equal(t, `"2024-05-01"`, string(out))
+	}
+
+	var line gosap.DocumentLine
+	require.NoError(t, json.Unmarshal([]byte(`{"ShipDate":null}`), &line))
+	assert.Nil(t, line.ShipDate)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"ShipDate":""}`), &line))
+	require.NotNil(t, line.ShipDate)
+	assert.True(t, line.ShipDate.IsZero())
+
+	out, err := json.Marshal(line)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"ShipDate":""`)
+
+	var d gosap.Date
+	assert.Error(t, json.Unmarshal([]byte(`"01/05/2024"`), &d))
+	assert.Equal(t, "'2024-05-01'", gosap.NewDate(2024, time.May, 1).Literal())
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{`"14:30:00"`, `"14:30"`, `"1430"`, `"14:30:00.000"`, `"PT14H30M"`} {
+		var tod gosap.TimeOfDay
+		require.NoError(t, json.Unmarshal([]byte(in), &tod), in)
+		assert.Equal(t, "14:30:00", tod.String(), in)
+	}
+
+	midnight := gosap.NewTimeOfDay(0, 0, 0)
+	assert.False(t, midnight.IsZero())
+
+	out, err := json.Marshal(midnight)
+	require.NoError(t, err)
+	assert.Equal(t, `"00:00:00"`, string(out))
+
+	out, err = json.Marshal(gosap.TimeOfDay{})
+	require.NoError(t, err)
+	assert.Equal(t, `""`, string(out))
+}
+
+func TestCombineDateTime(t *testing.T) {
+	t.Parallel()
+
+	cfg := gosap.Config{TimeZone: "Africa/Tunis"}
+	date := gosap.NewDate(2024, time.May, 1)
+	clock := gosap.NewTimeOfDay(14, 30, 0)
+
+	combined := gosap.CombineDateTime(cfg, &date, &clock)
+	assert.Equal(t, "2024-05-01T14:30:00+01:00", combined.Format(time.RFC3339))
+	assert.True(t, gosap.CombineDateTime(cfg, nil, &clock).IsZero())
+}
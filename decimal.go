@@ -0,0 +1,385 @@
+package gosap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number for quantities, prices and amounts. It holds the
+// canonical text of the number, so values read from the Service Layer are written back
+// exactly, and the empty Decimal is zero and omitted like a zero float64 with omitempty.
+//
+// Compare values with Cmp or Equal: "1.5" and "1.50" built by hand are the same number but
+// different strings. Arithmetic on a Decimal that isn't a number panics; values built with
+// the constructors or read from JSON always are. Functions taking decimals built by the caller,
+// such as ResolvePrice and BuildCopy, check them with Validate and return an error instead.
+type Decimal string
+
+// Zero is the zero Decimal.
+const Zero Decimal = ""
+
+var bigTen = big.NewInt(10)
+
+// maxDecimalExponent bounds the exponent of parsed decimals, so a value like 1e2000000000
+// can't make gosap allocate a number with billions of digits.
+const maxDecimalExponent = 1000
+
+// NewDecimal returns coefficient × 10^-scale, e.g. NewDecimal(1250, 2) is 12.50.
+func NewDecimal(coefficient int64, scale int32) Decimal {
+	return fromBig(big.NewInt(coefficient), scale)
+}
+
+// DecimalFromInt returns the integer n as a Decimal.
+func DecimalFromInt(n int64) Decimal {
+	return NewDecimal(n, 0)
+}
+
+// DecimalFromFloat returns the shortest decimal that reads back as f.
+func DecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// ParseDecimal reads a decimal number, including JSON numbers with an exponent.
+func ParseDecimal(s string) (Decimal, error) {
+	coefficient, scale, err := parseDecimal(s)
+	if err != nil {
+		return Zero, err
+	}
+
+	return fromBig(coefficient, scale), nil
+}
+
+func parseDecimal(s string) (*big.Int, int32, error) {
+	if s == "" {
+		return new(big.Int), 0, nil
+	}
+
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+
+		mantissa = s[:i]
+
+		exponent, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return nil, 0, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+
+	coefficient, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale := int64(len(fraction)) - exponent
+	if scale < 0 {
+		coefficient.Mul(coefficient, new(big.Int).Exp(bigTen, big.NewInt(-scale), nil))
+		scale = 0
+	}
+
+	return coefficient, int32(scale), nil
+}
+
+// fromBig formats coefficient × 10^-scale, dropping trailing fractional zeros.
+func fromBig(coefficient *big.Int, scale int32) Decimal {
+	if coefficient.Sign() == 0 {
+		return Zero
+	}
+
+	digits := new(big.Int).Abs(coefficient).String()
+
+	for scale > 0 && len(digits) > 1 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+
+	if scale > 0 {
+		if len(digits) <= int(scale) {
+			digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+		}
+
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+
+	if coefficient.Sign() < 0 {
+		digits = "-" + digits
+	}
+
+	return Decimal(digits)
+}
+
+// Validate returns an error when d isn't a number.
+func (d Decimal) Validate() error {
+	_, _, err := parseDecimal(string(d))
+
+	return err
+}
+
+func (d Decimal) parts() (*big.Int, int32) {
+	coefficient, scale, err := parseDecimal(string(d))
+	if err != nil {
+		panic(err)
+	}
+
+	return coefficient, scale
+}
+
+// align returns the coefficients of d and other at their common scale.
+func (d Decimal) align(other Decimal) (*big.Int, *big.Int, int32) {
+	a, as := d.parts()
+	b, bs := other.parts()
+
+	switch {
+	case as < bs:
+		a.Mul(a, new(big.Int).Exp(bigTen, big.NewInt(int64(bs-as)), nil))
+		as = bs
+	case bs < as:
+		b.Mul(b, new(big.Int).Exp(bigTen, big.NewInt(int64(as-bs)), nil))
+	}
+
+	return a, b, as
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := d.align(other)
+
+	return fromBig(a.Add(a, b), scale)
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := d.align(other)
+
+	return fromBig(a.Sub(a, b), scale)
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	a, as := d.parts()
+	b, bs := other.parts()
+
+	return fromBig(a.Mul(a, b), as+bs)
+}
+
+// Div returns d / other rounded half away from zero to places decimals. It panics when other
+// is zero.
+func (d Decimal) Div(other Decimal, places int32) Decimal {
+	a, as := d.parts()
+	b, bs := other.parts()
+
+	if b.Sign() == 0 {
+		panic("gosap: decimal division by zero")
+	}
+
+	// a×10^-as / b×10^-bs = (a×10^(places+1+bs-as) / b) × 10^-(places+1), then round off the
+	// extra digit.
+	shift := int64(places) + 1 + int64(bs) - int64(as)
+	if shift >= 0 {
+		a.Mul(a, new(big.Int).Exp(bigTen, big.NewInt(shift), nil))
+	} else {
+		b.Mul(b, new(big.Int).Exp(bigTen, big.NewInt(-shift), nil))
+	}
+
+	return fromBig(a.Quo(a, b), places+1).Round(places)
+}
+
+func (d Decimal) Neg() Decimal {
+	a, scale := d.parts()
+
+	return fromBig(a.Neg(a), scale)
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.Sign() < 0 {
+		return d.Neg()
+	}
+
+	return d.canonical()
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := d.align(other)
+
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and other are the same number.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) Sign() int {
+	a, _ := d.parts()
+
+	return a.Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Round rounds d half away from zero, like SAP does, to places decimals.
+func (d Decimal) Round(places int32) Decimal {
+	a, scale := d.parts()
+	if scale <= places {
+		return fromBig(a, scale)
+	}
+
+	divisor := new(big.Int).Exp(bigTen, big.NewInt(int64(scale-places)), nil)
+	quotient, remainder := new(big.Int).QuoRem(a, divisor, new(big.Int))
+
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(a.Sign())))
+	}
+
+	return fromBig(quotient, places)
+}
+
+// Float64 returns the nearest float64, for display or statistics only.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+
+	return f
+}
+
+func (d Decimal) canonical() Decimal {
+	a, scale := d.parts()
+
+	return fromBig(a, scale)
+}
+
+func (d Decimal) String() string {
+	if d == Zero {
+		return "0"
+	}
+
+	return string(d)
+}
+
+// SumDecimals adds up values.
+func SumDecimals(values ...Decimal) Decimal {
+	total := Zero
+	for _, v := range values {
+		total = total.Add(v)
+	}
+
+	return total
+}
+
+// MarshalJSON writes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if _, _, err := parseDecimal(string(d)); err != nil {
+		return nil, err
+	}
+
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, or a string holding one. null gives zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+
+		return nil
+	}
+
+	text := string(data)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// DecimalPrecision is the number of decimals a company keeps per kind of value, as set in the
+// general settings of SAP.
+type DecimalPrecision struct {
+	Amounts    int32 `mapstructure:"AMOUNT_DECIMALS"`
+	Prices     int32 `mapstructure:"PRICE_DECIMALS"`
+	Rates      int32 `mapstructure:"RATE_DECIMALS"`
+	Quantities int32 `mapstructure:"QUANTITY_DECIMALS"`
+	Percents   int32 `mapstructure:"PERCENT_DECIMALS"`
+	Measures   int32 `mapstructure:"MEASURE_DECIMALS"`
+}
+
+// DefaultDecimalPrecision is used when a Config sets no precision.
+var DefaultDecimalPrecision = DecimalPrecision{
+	Amounts:    2,
+	Prices:     2,
+	Rates:      4,
+	Quantities: 3,
+	Percents:   2,
+	Measures:   3,
+}
+
+func (p DecimalPrecision) RoundAmount(d Decimal) Decimal {
+	return d.Round(p.Amounts)
+}
+
+func (p DecimalPrecision) RoundPrice(d Decimal) Decimal {
+	return d.Round(p.Prices)
+}
+
+func (p DecimalPrecision) RoundRate(d Decimal) Decimal {
+	return d.Round(p.Rates)
+}
+
+func (p DecimalPrecision) RoundQuantity(d Decimal) Decimal {
+	return d.Round(p.Quantities)
+}
+
+func (p DecimalPrecision) RoundPercent(d Decimal) Decimal {
+	return d.Round(p.Percents)
+}
+
+func (p DecimalPrecision) RoundMeasure(d Decimal) Decimal {
+	return d.Round(p.Measures)
+}
+
+// adminInfo holds the accuracy settings of CompanyService_GetAdminInfo.
+type adminInfo struct {
+	TotalsAccuracy     int32
+	PriceAccuracy      int32
+	RateAccuracy       int32
+	QuantityAccuracy   int32
+	PercentageAccuracy int32
+	MeasuringAccuracy  int32
+}
+
+// GetDecimalPrecision reads the decimal settings of the company, e.g. to fill cfg.Precision
+// once after logging in.
+func (s *Session) GetDecimalPrecision(cfg Config) (DecimalPrecision, error) {
+	info, err := createDocument[adminInfo](s, cfg.AdminInfoEndpoint(), struct{}{})
+	if err != nil {
+		return DecimalPrecision{}, fmt.Errorf("could not fetch company admin info due to %w", err)
+	}
+
+	return DecimalPrecision{
+		Amounts:    info.TotalsAccuracy,
+		Prices:     info.PriceAccuracy,
+		Rates:      info.RateAccuracy,
+		Quantities: info.QuantityAccuracy,
+		Percents:   info.PercentageAccuracy,
+		Measures:   info.MeasuringAccuracy,
+	}, nil
+}
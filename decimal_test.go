@@ -0,0 +1,110 @@
+package gosap_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want gosap.Decimal
+	}{
+		{"12.50", "12.5"},
+		{"0.000", gosap.Zero},
+		{"-.5", "-0.5"},
+		{"+3", "3"},
+		{"1.5e3", "1500"},
+		{"125E-4", "0.0125"},
+		{"", gosap.Zero},
+	}
+
+	for _, tt := range tests {
+		got, err := gosap.ParseDecimal(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, in := range []string{"1,5", "abc", "1.2.3", "1e", ".", "1e1001", "1e-1001", "1e2000000000"} {
+		_, err := gosap.ParseDecimal(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	t.Parallel()
+
+	// 0.1 + 0.2 drifts as float64.
+	assert.Equal(t, gosap.Decimal("0.3"), gosap.Decimal("0.1").Add("0.2"))
+	assert.Equal(t, gosap.Decimal("-1.25"), gosap.Decimal("1").Sub("2.25"))
+	assert.Equal(t, gosap.Decimal("3.075"), gosap.Decimal("1.23").Mul("2.5"))
+	assert.Equal(t, gosap.Decimal("0.33"), gosap.Decimal("1").Div("3", 2))
+	assert.Equal(t, gosap.Decimal("-0.67"), gosap.Decimal("-2").Div("3", 2))
+	assert.Equal(t, gosap.Decimal("6"), gosap.SumDecimals("1.5", "2.25", "2.25"))
+
+	assert.Equal(t, 0, gosap.Decimal("1.50").Cmp("1.5"))
+	assert.True(t, gosap.Decimal("2").Equal(gosap.DecimalFromInt(2)))
+	assert.Equal(t, -1, gosap.Zero.Cmp("0.001"))
+	assert.Equal(t, gosap.Decimal("12.5"), gosap.NewDecimal(1250, 2))
+	assert.Equal(t, gosap.Decimal("0.1"), gosap.DecimalFromFloat(0.1))
+	assert.Equal(t, "0", gosap.Zero.String())
+
+	assert.Panics(t, func() { gosap.Decimal("1").Div(gosap.Zero, 2) })
+}
+
+func TestDecimalRound(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, gosap.Decimal("2.35"), gosap.Decimal("2.345").Round(2))
+	assert.Equal(t, gosap.Decimal("-2.35"), gosap.Decimal("-2.345").Round(2))
+	assert.Equal(t, gosap.Decimal("2.34"), gosap.Decimal("2.3449").Round(2))
+	assert.Equal(t, gosap.Decimal("1.2"), gosap.Decimal("1.2").Round(4))
+	assert.Equal(t, gosap.Decimal("1000"), gosap.Decimal("999.5").Round(0))
+
+	cfg := gosap.Config{}
+	assert.Equal(t, gosap.DefaultDecimalPrecision, cfg.Decimals())
+	assert.Equal(t, gosap.Decimal("10.13"), cfg.Decimals().RoundAmount("10.125"))
+	assert.Equal(t, gosap.Decimal("1.2346"), cfg.Decimals().RoundRate("1.23456"))
+
+	cfg.Precision = gosap.DecimalPrecision{Quantities: 1}
+	assert.Equal(t, gosap.Decimal("3"), cfg.Decimals().RoundAmount("2.5"))
+	assert.Equal(t, gosap.Decimal("2.5"), cfg.Decimals().RoundQuantity("2.46"))
+}
+
+func TestDecimalJSON(t *testing.T) {
+	t.Parallel()
+
+	var line struct {
+		Quantity  gosap.Decimal
+		Price     gosap.Decimal
+		Discount  gosap.Decimal
+		LineTotal gosap.Decimal `json:",omitempty"`
+	}
+
+	payload := `{"Quantity":12345678901234567.125,"Price":"9.99","Discount":null,"LineTotal":0.0}`
+	require.NoError(t, json.Unmarshal([]byte(payload), &line))
+	assert.Equal(t, gosap.Decimal("12345678901234567.125"), line.Quantity)
+	assert.Equal(t, gosap.Decimal("9.99"), line.Price)
+	assert.True(t, line.Discount.IsZero())
+
+	out, err := json.Marshal(line)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Quantity":12345678901234567.125,"Price":9.99,"Discount":0}`, string(out))
+
+	_, err = json.Marshal(gosap.Decimal("12,5"))
+	assert.Error(t, err)
+}
+
+func TestDecimalValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, gosap.Decimal("12.5").Validate())
+	assert.NoError(t, gosap.Zero.Validate())
+	assert.Error(t, gosap.Decimal("ten").Validate())
+}
@@ -0,0 +1,128 @@
+package gosap_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderDraft(t *testing.T, session *gosap.Session, cfg gosap.Config) *gosap.Draft {
+	t.Helper()
+
+	draft, err := session.CreateDraft(cfg, gosap.ObjectOrders, gosap.Document{
+		DocumentHeader: gosap.DocumentHeader{CardCode: "C20000"},
+		DocumentLines:  []gosap.DocumentLine{{ItemCode: "A00001", Quantity: "4"}},
+	})
+	require.NoError(t, err)
+
+	return draft
+}
+
+func TestDraftToDocument(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	draft := newOrderDraft(t, session, cfg)
+
+	create := lastRequest(t, sent(), http.MethodPost)
+	assert.Equal(t, "/b1s/v1/Drafts", create.Path)
+	assert.Equal(t, "oOrders", create.Body["DocObjectCode"])
+	assert.Equal(t, "C20000", create.Body["CardCode"])
+
+	assert.NotZero(t, draft.DocEntry)
+	assert.Equal(t, gosap.ObjectOrders, draft.DocObjectCode)
+	require.Len(t, draft.DocumentLines, 1)
+	assert.Equal(t, gosap.Decimal("4"), draft.DocumentLines[0].Quantity)
+
+	require.NoError(t, session.UpdateDraft(cfg, draft.DocEntry, gosap.Document{
+		DocumentHeader: gosap.DocumentHeader{Comments: "Deliver before noon"},
+	}))
+
+	updated, err := session.GetDraft(cfg, draft.DocEntry)
+	require.NoError(t, err)
+	assert.Equal(t, "Deliver before noon", updated.Comments)
+
+	require.NoError(t, session.SaveDraftToDocument(cfg, draft.DocEntry))
+
+	save := lastRequest(t, sent(), http.MethodPost)
+	assert.Equal(t, "/b1s/v1/DraftsService_SaveDraftToDocument", save.Path)
+	assert.Equal(t, map[string]any{"Document": map[string]any{"DocEntry": float64(draft.DocEntry)}}, save.Body)
+
+	orders := server.Entities("Orders")
+	require.Len(t, orders, 1)
+	assert.Equal(t, "C20000", orders[0]["CardCode"])
+	assert.Equal(t, "Deliver before noon", orders[0]["Comments"])
+
+	saved, err := session.GetDraft(cfg, draft.DocEntry)
+	require.NoError(t, err)
+	assert.True(t, saved.IsClosed())
+}
+
+func TestApprovalRequestDecisions(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	approved := newOrderDraft(t, session, cfg)
+	rejected := newOrderDraft(t, session, cfg)
+
+	require.NoError(t, server.Seed("ApprovalRequests",
+		gosap.ApprovalRequest{Code: 1, ObjectType: "17", IsDraft: gosap.BoYes, DraftEntry: approved.DocEntry,
+			Status: gosap.ApprovalRequestPending},
+		gosap.ApprovalRequest{Code: 2, ObjectType: "17", IsDraft: gosap.BoYes, DraftEntry: rejected.DocEntry,
+			Status: gosap.ApprovalRequestPending},
+	))
+
+	pending, err := session.GetPendingApprovalRequests(cfg)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	require.Error(t, session.SaveDraftToDocument(cfg, approved.DocEntry))
+
+	require.NoError(t, session.ApproveApprovalRequest(cfg, 1, "Within budget"))
+
+	patch := lastRequest(t, sent(), http.MethodPatch)
+	assert.Equal(t, "/b1s/v1/ApprovalRequests(1)", patch.Path)
+	assert.Equal(t, map[string]any{"ApprovalRequestDecisions": []any{
+		map[string]any{"Status": "ardApproved", "Remarks": "Within budget"},
+	}}, patch.Body)
+
+	require.NoError(t, session.RejectApprovalRequest(cfg, 2, "Over budget"))
+
+	request, err := session.GetApprovalRequest(cfg, 1)
+	require.NoError(t, err)
+	assert.Equal(t, gosap.ApprovalRequestApproved, request.Status)
+
+	request, err = session.GetApprovalRequest(cfg, 2)
+	require.NoError(t, err)
+	assert.Equal(t, gosap.ApprovalRequestNotApproved, request.Status)
+	require.Len(t, request.ApprovalRequestDecisions, 1)
+	assert.Equal(t, gosap.ApprovalDecisionNotApproved, request.ApprovalRequestDecisions[0].Status)
+
+	pending, err = session.GetPendingApprovalRequests(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, session.SaveDraftToDocument(cfg, approved.DocEntry))
+	require.Error(t, session.SaveDraftToDocument(cfg, rejected.DocEntry))
+	assert.Len(t, server.Entities("Orders"), 1)
+}
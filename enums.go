@@ -0,0 +1,409 @@
+package gosap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// The enum types below hold the names the Service Layer uses for its Bo* enumerations. The
+// empty value means unset. Decoding any other unknown value fails, so a typo or an unexpected
+// value doesn't silently pass as "not open" or "not valid". Literal quotes a value for a
+// $filter expression, e.g. DocumentStatus eq 'bost_Open'.
+
+// BoYesNoEnum is a Service Layer boolean.
+type BoYesNoEnum string
+
+const (
+	BoYes BoYesNoEnum = "tYES"
+	BoNo  BoYesNoEnum = "tNO"
+)
+
+var boYesNoValues = []BoYesNoEnum{BoYes, BoNo}
+
+func (e BoYesNoEnum) IsValid() bool {
+	return e == "" || slices.Contains(boYesNoValues, e)
+}
+
+func (e BoYesNoEnum) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoYesNoEnum) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boYesNoValues)
+}
+
+// BoStatus is the status of a marketing document or of one of its lines.
+type BoStatus string
+
+const (
+	BoStatusOpen      BoStatus = "bost_Open"
+	BoStatusClose     BoStatus = "bost_Close"
+	BoStatusPaid      BoStatus = "bost_Paid"
+	BoStatusDelivered BoStatus = "bost_Delivered"
+)
+
+var boStatusValues = []BoStatus{BoStatusOpen, BoStatusClose, BoStatusPaid, BoStatusDelivered}
+
+func (e BoStatus) IsValid() bool {
+	return e == "" || slices.Contains(boStatusValues, e)
+}
+
+func (e BoStatus) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boStatusValues)
+}
+
+// BoDocumentTypes tells whether a marketing document has item or service lines.
+type BoDocumentTypes string
+
+const (
+	DocumentTypeItems   BoDocumentTypes = "dDocument_Items"
+	DocumentTypeService BoDocumentTypes = "dDocument_Service"
+)
+
+var boDocumentTypesValues = []BoDocumentTypes{DocumentTypeItems, DocumentTypeService}
+
+func (e BoDocumentTypes) IsValid() bool {
+	return e == "" || slices.Contains(boDocumentTypesValues, e)
+}
+
+func (e BoDocumentTypes) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoDocumentTypes) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boDocumentTypesValues)
+}
+
+// BoCardTypes is the kind of a business partner.
+type BoCardTypes string
+
+const (
+	CardTypeCustomer BoCardTypes = "cCustomer"
+	CardTypeSupplier BoCardTypes = "cSupplier"
+	CardTypeLead     BoCardTypes = "cLid"
+)
+
+var boCardTypesValues = []BoCardTypes{CardTypeCustomer, CardTypeSupplier, CardTypeLead}
+
+func (e BoCardTypes) IsValid() bool {
+	return e == "" || slices.Contains(boCardTypesValues, e)
+}
+
+func (e BoCardTypes) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoCardTypes) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boCardTypesValues)
+}
+
+// BoVatStatus is the VAT liability of a business partner.
+type BoVatStatus string
+
+const (
+	VatStatusLiable   BoVatStatus = "vLiable"
+	VatStatusExempted BoVatStatus = "vExempted"
+	VatStatusEC       BoVatStatus = "vEC"
+)
+
+var boVatStatusValues = []BoVatStatus{VatStatusLiable, VatStatusExempted, VatStatusEC}
+
+func (e BoVatStatus) IsValid() bool {
+	return e == "" || slices.Contains(boVatStatusValues, e)
+}
+
+func (e BoVatStatus) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoVatStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boVatStatusValues)
+}
+
+// BoAddressType tells bill-to and ship-to addresses apart.
+type BoAddressType string
+
+const (
+	AddressTypeShipTo BoAddressType = "bo_ShipTo"
+	AddressTypeBillTo BoAddressType = "bo_BillTo"
+)
+
+var boAddressTypeValues = []BoAddressType{AddressTypeShipTo, AddressTypeBillTo}
+
+func (e BoAddressType) IsValid() bool {
+	return e == "" || slices.Contains(boAddressTypeValues, e)
+}
+
+func (e BoAddressType) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoAddressType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boAddressTypeValues)
+}
+
+// BoItemTypes is the kind of an item.
+type BoItemTypes string
+
+const (
+	ItemTypeItems       BoItemTypes = "itItems"
+	ItemTypeLabor       BoItemTypes = "itLabor"
+	ItemTypeTravel      BoItemTypes = "itTravel"
+	ItemTypeFixedAssets BoItemTypes = "itFixedAssets"
+)
+
+var boItemTypesValues = []BoItemTypes{ItemTypeItems, ItemTypeLabor, ItemTypeTravel, ItemTypeFixedAssets}
+
+func (e BoItemTypes) IsValid() bool {
+	return e == "" || slices.Contains(boItemTypesValues, e)
+}
+
+func (e BoItemTypes) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoItemTypes) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, boItemTypesValues)
+}
+
+// CountingTypeEnum tells whether an inventory counting has one or several counters.
+type CountingTypeEnum string
+
+const (
+	CountingTypeSingle   CountingTypeEnum = "ctSingleCounter"
+	CountingTypeMultiple CountingTypeEnum = "ctMultipleCounters"
+)
+
+var countingTypeValues = []CountingTypeEnum{CountingTypeSingle, CountingTypeMultiple}
+
+func (e CountingTypeEnum) IsValid() bool {
+	return e == "" || slices.Contains(countingTypeValues, e)
+}
+
+func (e CountingTypeEnum) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *CountingTypeEnum) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, countingTypeValues)
+}
+
+// CountingDocumentStatusEnum is the status of an inventory counting.
+type CountingDocumentStatusEnum string
+
+const (
+	CountingStatusOpen   CountingDocumentStatusEnum = "cdsOpen"
+	CountingStatusClosed CountingDocumentStatusEnum = "cdsClosed"
+)
+
+var countingDocumentStatusValues = []CountingDocumentStatusEnum{CountingStatusOpen, CountingStatusClosed}
+
+func (e CountingDocumentStatusEnum) IsValid() bool {
+	return e == "" || slices.Contains(countingDocumentStatusValues, e)
+}
+
+func (e CountingDocumentStatusEnum) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *CountingDocumentStatusEnum) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, countingDocumentStatusValues)
+}
+
+// BoApprovalRequestStatusEnum is the status of an approval request.
+type BoApprovalRequestStatusEnum string
+
+const (
+	ApprovalRequestPending               BoApprovalRequestStatusEnum = "arsPending"
+	ApprovalRequestApproved              BoApprovalRequestStatusEnum = "arsApproved"
+	ApprovalRequestNotApproved           BoApprovalRequestStatusEnum = "arsNotApproved"
+	ApprovalRequestGenerated             BoApprovalRequestStatusEnum = "arsGenerated"
+	ApprovalRequestGeneratedByAuthorizer BoApprovalRequestStatusEnum = "arsGeneratedByAuthorizer"
+	ApprovalRequestCancelled             BoApprovalRequestStatusEnum = "arsCancelled"
+)
+
+var approvalRequestStatusValues = []BoApprovalRequestStatusEnum{ApprovalRequestPending, ApprovalRequestApproved, ApprovalRequestNotApproved, ApprovalRequestGenerated, ApprovalRequestGeneratedByAuthorizer, ApprovalRequestCancelled}
+
+func (e BoApprovalRequestStatusEnum) IsValid() bool {
+	return e == "" || slices.Contains(approvalRequestStatusValues, e)
+}
+
+func (e BoApprovalRequestStatusEnum) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoApprovalRequestStatusEnum) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, approvalRequestStatusValues)
+}
+
+// BoApprovalRequestDecisionEnum is the decision of an approver.
+type BoApprovalRequestDecisionEnum string
+
+const (
+	ApprovalDecisionPending     BoApprovalRequestDecisionEnum = "ardPending"
+	ApprovalDecisionApproved    BoApprovalRequestDecisionEnum = "ardApproved"
+	ApprovalDecisionNotApproved BoApprovalRequestDecisionEnum = "ardNotApproved"
+)
+
+var approvalDecisionValues = []BoApprovalRequestDecisionEnum{ApprovalDecisionPending, ApprovalDecisionApproved, ApprovalDecisionNotApproved}
+
+func (e BoApprovalRequestDecisionEnum) IsValid() bool {
+	return e == "" || slices.Contains(approvalDecisionValues, e)
+}
+
+func (e BoApprovalRequestDecisionEnum) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoApprovalRequestDecisionEnum) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, approvalDecisionValues)
+}
+
+// BoUTBTableType is the kind of a user-defined table.
+type BoUTBTableType string
+
+const (
+	UserTableNoObject              BoUTBTableType = "bott_NoObject"
+	UserTableNoObjectAutoIncrement BoUTBTableType = "bott_NoObjectAutoIncrement"
+	UserTableMasterData            BoUTBTableType = "bott_MasterData"
+	UserTableMasterDataLines       BoUTBTableType = "bott_MasterDataLines"
+	UserTableDocument              BoUTBTableType = "bott_Document"
+	UserTableDocumentLines         BoUTBTableType = "bott_DocumentLines"
+)
+
+var userTableTypeValues = []BoUTBTableType{UserTableNoObject, UserTableNoObjectAutoIncrement, UserTableMasterData, UserTableMasterDataLines, UserTableDocument, UserTableDocumentLines}
+
+func (e BoUTBTableType) IsValid() bool {
+	return e == "" || slices.Contains(userTableTypeValues, e)
+}
+
+func (e BoUTBTableType) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoUTBTableType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, userTableTypeValues)
+}
+
+// BoFieldTypes is the type of a user-defined field.
+type BoFieldTypes string
+
+const (
+	FieldTypeAlpha   BoFieldTypes = "db_Alpha"
+	FieldTypeMemo    BoFieldTypes = "db_Memo"
+	FieldTypeNumeric BoFieldTypes = "db_Numeric"
+	FieldTypeDate    BoFieldTypes = "db_Date"
+	FieldTypeFloat   BoFieldTypes = "db_Float"
+)
+
+var fieldTypeValues = []BoFieldTypes{FieldTypeAlpha, FieldTypeMemo, FieldTypeNumeric, FieldTypeDate, FieldTypeFloat}
+
+func (e BoFieldTypes) IsValid() bool {
+	return e == "" || slices.Contains(fieldTypeValues, e)
+}
+
+func (e BoFieldTypes) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoFieldTypes) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, fieldTypeValues)
+}
+
+// BoFldSubTypes refines the type of a user-defined field.
+type BoFldSubTypes string
+
+const (
+	FieldSubTypeNone        BoFldSubTypes = "st_None"
+	FieldSubTypeAddress     BoFldSubTypes = "st_Address"
+	FieldSubTypePhone       BoFldSubTypes = "st_Phone"
+	FieldSubTypeTime        BoFldSubTypes = "st_Time"
+	FieldSubTypeRate        BoFldSubTypes = "st_Rate"
+	FieldSubTypeSum         BoFldSubTypes = "st_Sum"
+	FieldSubTypePrice       BoFldSubTypes = "st_Price"
+	FieldSubTypeQuantity    BoFldSubTypes = "st_Quantity"
+	FieldSubTypePercentage  BoFldSubTypes = "st_Percentage"
+	FieldSubTypeMeasurement BoFldSubTypes = "st_Measurement"
+	FieldSubTypeLink        BoFldSubTypes = "st_Link"
+	FieldSubTypeImage       BoFldSubTypes = "st_Image"
+	FieldSubTypeCheckbox    BoFldSubTypes = "st_Checkbox"
+)
+
+var fieldSubTypeValues = []BoFldSubTypes{FieldSubTypeNone, FieldSubTypeAddress, FieldSubTypePhone, FieldSubTypeTime, FieldSubTypeRate, FieldSubTypeSum, FieldSubTypePrice, FieldSubTypeQuantity, FieldSubTypePercentage, FieldSubTypeMeasurement, FieldSubTypeLink, FieldSubTypeImage, FieldSubTypeCheckbox}
+
+func (e BoFldSubTypes) IsValid() bool {
+	return e == "" || slices.Contains(fieldSubTypeValues, e)
+}
+
+func (e BoFldSubTypes) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoFldSubTypes) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, fieldSubTypeValues)
+}
+
+// BoUDOObjType is the kind of a user-defined object.
+type BoUDOObjType string
+
+const (
+	UserObjectMasterData BoUDOObjType = "boud_MasterData"
+	UserObjectDocument   BoUDOObjType = "boud_Document"
+)
+
+var userObjectTypeValues = []BoUDOObjType{UserObjectMasterData, UserObjectDocument}
+
+func (e BoUDOObjType) IsValid() bool {
+	return e == "" || slices.Contains(userObjectTypeValues, e)
+}
+
+func (e BoUDOObjType) Literal() string {
+	return quoteKey(string(e))
+}
+
+func (e *BoUDOObjType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, e, userObjectTypeValues)
+}
+
+// YesNo returns BoYes for true and BoNo for false.
+func YesNo(b bool) BoYesNoEnum {
+	if b {
+		return BoYes
+	}
+
+	return BoNo
+}
+
+// Bool reports whether e is BoYes.
+func (e BoYesNoEnum) Bool() bool {
+	return e == BoYes
+}
+
+// unmarshalEnum decodes a JSON string into v, rejecting values not in values. null and "" give
+// the unset value.
+func unmarshalEnum[T ~string](data []byte, v *T, values []T) error {
+	if bytes.Equal(data, []byte("null")) {
+		*v = ""
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid %T %s", *v, data)
+	}
+
+	if s != "" && !slices.Contains(values, T(s)) {
+		return fmt.Errorf("invalid %T %q", *v, s)
+	}
+
+	*v = T(s)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package gosap_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var note gosap.DeliveryNote
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"DocEntry":1,"DocType":"dDocument_Items","DocumentStatus":"bost_Close","Cancelled":"tYES"}`), &note))
+
+	assert.Equal(t, gosap.DocumentTypeItems, note.DocType)
+	assert.True(t, note.IsClosed())
+	assert.False(t, note.IsOpen())
+	assert.True(t, note.IsCancelled())
+
+	err := json.Unmarshal([]byte(`{"DocEntry":1,"DocumentStatus":"bost_Opened"}`), &note)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `gosap.BoStatus "bost_Opened"`)
+
+	var counting gosap.InventoryCounting
+	require.NoError(t, json.Unmarshal([]byte(`{"CountingType":"ctMultipleCounters","DocumentStatus":null}`), &counting))
+	assert.Equal(t, gosap.CountingTypeMultiple, counting.CountingType)
+	assert.False(t, counting.IsOpen())
+
+	require.Error(t, json.Unmarshal([]byte(`{"CountingType":"multiple"}`), &counting))
+}
+
+func TestDocumentStatusOnEveryDocument(t *testing.T) {
+	t.Parallel()
+
+	open := gosap.DocumentHeader{Status: gosap.BoStatusOpen, Cancelled: gosap.BoNo}
+
+	order := gosap.PurchaseOrder{DocumentHeader: open}
+	receipt := gosap.PurchaseDeliveryNote{DocumentHeader: open}
+	document := gosap.Document{DocumentHeader: open}
+	draft := gosap.Draft{DocumentHeader: open}
+
+	assert.True(t, order.IsOpen())
+	assert.True(t, receipt.IsOpen())
+	assert.True(t, document.IsOpen())
+	assert.True(t, draft.IsOpen())
+	assert.False(t, order.IsCancelled())
+}
+
+func TestEnumHelpers(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "'cSupplier'", gosap.CardTypeSupplier.Literal())
+	assert.Equal(t, gosap.BoYes, gosap.YesNo(true))
+	assert.False(t, gosap.BoNo.Bool())
+	assert.True(t, gosap.BoStatus("").IsValid())
+	assert.False(t, gosap.BoYesNoEnum("Y").IsValid())
+}
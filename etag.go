@@ -0,0 +1,184 @@
+package gosap
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// ErrConflict is returned, wrapped, when a PATCH or DELETE is refused because the entity was
+// changed since the session read it. Test for it with errors.Is.
+var ErrConflict = errors.New("entity was changed since it was read")
+
+// maxETags bounds the ETags a session keeps. Past it, the least recently used one is dropped
+// and the next write of that entity is sent without If-Match.
+const maxETags = 1024
+
+// etagCache holds the last ETag read per entity, dropping the least recently used ones past
+// limit, or maxETags when zero, so sessions reading many entities don't grow without bound.
+type etagCache struct {
+	mu      sync.Mutex
+	limit   int
+	entries map[string]*list.Element
+	order   list.List
+}
+
+type etagEntry struct {
+	key, etag string
+}
+
+func (c *etagCache) load(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return ""
+	}
+
+	c.order.MoveToFront(elem)
+
+	return elem.Value.(*etagEntry).etag //nolint:forcetypeassert
+}
+
+func (c *etagCache) store(key, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*etagEntry).etag = etag //nolint:forcetypeassert
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	if c.entries == nil {
+		c.entries = map[string]*list.Element{}
+	}
+
+	c.entries[key] = c.order.PushFront(&etagEntry{key: key, etag: etag})
+
+	limit := c.limit
+	if limit == 0 {
+		limit = maxETags
+	}
+
+	for len(c.entries) > limit {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagEntry).key) //nolint:forcetypeassert
+	}
+}
+
+func (c *etagCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// etagKey identifies an entity by its URL without query, so reading it with $select and
+// updating it share the same ETag.
+func etagKey(u *url.URL) string {
+	return u.Scheme + "://" + u.Host + u.EscapedPath()
+}
+
+// ETag returns the ETag of the entity at endpoint as last read by the session, if any.
+func (s *Session) ETag(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+
+	return s.root().etags.load(etagKey(u))
+}
+
+// ForgetETag drops the ETag of the entity at endpoint, so the next PATCH or DELETE of it is
+// sent without If-Match and overwrites whatever changed.
+func (s *Session) ForgetETag(endpoint string) {
+	if u, err := url.Parse(endpoint); err == nil {
+		s.root().etags.delete(etagKey(u))
+	}
+}
+
+// setIfMatch sends the ETag read for the entity with PATCH and DELETE requests, unless the
+// caller set If-Match already or the request is unconditional.
+func (s *Session) setIfMatch(req *http.Request, conditional bool) {
+	if !conditional || req.Method != http.MethodPatch && req.Method != http.MethodDelete {
+		return
+	}
+
+	if req.Header.Get("If-Match") != "" {
+		return
+	}
+
+	if etag := s.ETag(req.URL.String()); etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+}
+
+// trackETag records the ETag of entities read and drops the one of entities changed, since it
+// is stale once the change went through.
+func (s *Session) trackETag(req *http.Request, resp *http.Response) {
+	key := etagKey(req.URL)
+	etag := resp.Header.Get("ETag")
+
+	switch req.Method {
+	case http.MethodGet, http.MethodPatch:
+		if etag != "" {
+			s.root().etags.store(key, etag)
+		} else {
+			s.root().etags.delete(key)
+		}
+	case http.MethodDelete:
+		s.root().etags.delete(key)
+	}
+}
+
+// RetryOnConflict runs update, a closure that reads an entity, changes it and writes it back,
+// and runs it again while the write fails with ErrConflict, at most attempts times in all.
+// Reading the entity again inside update picks up the ETag of the latest version:
+//
+//	err := gosap.RetryOnConflict(3, func() error {
+//		counting, err := session.GetInventoryCounting(cfg, id)
+//		if err != nil {
+//			return err
+//		}
+//		...
+//		return session.PatchInventoryCounting(cfg, id, patch)
+//	})
+func RetryOnConflict(attempts int, update func() error) error {
+	return RetryOnConflictContext(context.Background(), attempts, func(context.Context) error {
+		return update()
+	})
+}
+
+type retryAttemptKey struct{}
+
+// RetryOnConflictContext is RetryOnConflict passing update a context recording the attempt,
+// counting from 1. Sessions derived from it with WithContext report the attempt in traces.
+func RetryOnConflictContext(ctx context.Context, attempts int, update func(ctx context.Context) error) error {
+	var err error
+
+	for attempt := 1; attempt <= max(attempts, 1); attempt++ {
+		err = update(context.WithValue(ctx, retryAttemptKey{}, attempt))
+		if !errors.Is(err, ErrConflict) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// RetryAttempt returns the attempt of RetryOnConflictContext ctx belongs to, or 0 outside of it.
+func RetryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+
+	return attempt
+}
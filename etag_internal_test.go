@@ -0,0 +1,29 @@
+package gosap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETagCacheDropsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache := etagCache{limit: 2}
+	cache.store("1", `W/"1"`)
+	cache.store("2", `W/"2"`)
+
+	// Reading 1 again keeps it, so 2 is the least recently used one.
+	assert.Equal(t, `W/"1"`, cache.load("1"))
+
+	cache.store("3", `W/"3"`)
+
+	assert.Equal(t, `W/"1"`, cache.load("1"))
+	assert.Empty(t, cache.load("2"))
+	assert.Equal(t, `W/"3"`, cache.load("3"))
+
+	// Storing a known key updates it in place without dropping another one.
+	cache.store("1", `W/"4"`)
+	assert.Equal(t, `W/"4"`, cache.load("1"))
+	assert.Equal(t, `W/"3"`, cache.load("3"))
+}
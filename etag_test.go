@@ -0,0 +1,128 @@
+package gosap_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedCounting serves inventory counting 1 with an ETag that changes on every PATCH.
+// bump simulates a change by someone else.
+type versionedCounting struct {
+	mu       sync.Mutex
+	version  int
+	ifMatch  []string
+	failures int
+}
+
+func (v *versionedCounting) bump() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.version++
+}
+
+func (v *versionedCounting) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	etag := fmt.Sprintf(`W/"%d"`, v.version)
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, `{"DocumentEntry":1,"DocumentStatus":"cdsOpen"}`)
+	case http.MethodPatch:
+		v.ifMatch = append(v.ifMatch, r.Header.Get("If-Match"))
+		if match := r.Header.Get("If-Match"); match != "" && match != etag {
+			v.failures++
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `{"error":{"code":-1,"message":{"value":"Data has been changed"}}}`)
+
+			return
+		}
+
+		v.version++
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestETagIfMatch(t *testing.T) {
+	t.Parallel()
+
+	counting := &versionedCounting{}
+	cfg := serviceLayerStub(t, counting.ServeHTTP)
+	session := &gosap.Session{}
+
+	_, err := session.GetInventoryCounting(cfg, 1)
+	require.NoError(t, err)
+	assert.Equal(t, `W/"0"`, session.ETag(cfg.GetInventoryCountingEndpoint(1)))
+
+	counting.bump()
+
+	err = session.PatchInventoryCounting(cfg, 1, gosap.NewPatch().Set("Remarks", "A"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, gosap.ErrConflict))
+	assert.Empty(t, session.ETag(cfg.GetInventoryCountingEndpoint(1)))
+	assert.Equal(t, []string{`W/"0"`}, counting.ifMatch)
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	t.Parallel()
+
+	counting := &versionedCounting{}
+	cfg := serviceLayerStub(t, counting.ServeHTTP)
+	session := &gosap.Session{}
+
+	attempts := 0
+	err := gosap.RetryOnConflict(3, func() error {
+		attempts++
+
+		if _, err := session.GetInventoryCounting(cfg, 1); err != nil {
+			return err
+		}
+
+		if attempts == 1 {
+			counting.bump()
+		}
+
+		return session.PatchInventoryCounting(cfg, 1, gosap.NewPatch().Set("Remarks", "B"))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, counting.failures)
+	assert.Equal(t, []string{`W/"0"`, `W/"1"`}, counting.ifMatch)
+
+	attempts = 0
+	err = gosap.RetryOnConflict(2, func() error {
+		attempts++
+
+		return fmt.Errorf("wrapped: %w", gosap.ErrConflict)
+	})
+	assert.ErrorIs(t, err, gosap.ErrConflict)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestAddLinesToInventoryCountingWithoutIfMatch(t *testing.T) {
+	t.Parallel()
+
+	counting := &versionedCounting{}
+	cfg := serviceLayerStub(t, counting.ServeHTTP)
+	session := &gosap.Session{}
+
+	_, err := session.GetInventoryCounting(cfg, 1)
+	require.NoError(t, err)
+
+	counting.bump()
+
+	_, err = session.AddLinesToInventoryCounting(cfg, 1, []gosap.InventoryCountingLine{{ItemCode: "A00001"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, counting.ifMatch)
+	assert.Empty(t, session.ETag(cfg.GetInventoryCountingEndpoint(1)))
+}
@@ -0,0 +1,197 @@
+package gosap
+
+// LineFulfilment summarizes how much of a document line was delivered, received or invoiced
+// by target documents.
+type LineFulfilment struct {
+	LineNum    int
+	ItemCode   string
+	Quantity   Decimal
+	Fulfilled  Decimal
+	Open       Decimal
+	OpenAmount Decimal
+	Closed     bool
+}
+
+// DocumentFulfilment summarizes the fulfilment of every line of a document.
+type DocumentFulfilment struct {
+	DocEntry   int
+	Quantity   Decimal
+	Fulfilled  Decimal
+	Open       Decimal
+	OpenAmount Decimal
+	Lines      []LineFulfilment
+}
+
+// Complete reports whether nothing is left open on the document.
+func (f DocumentFulfilment) Complete() bool {
+	return f.Open.IsZero()
+}
+
+// DocumentReference points to another document by object type and DocEntry.
+type DocumentReference struct {
+	ObjectType int
+	DocEntry   int
+}
+
+// Kind returns the document kind of the reference, if gosap knows it.
+func (r DocumentReference) Kind() (DocumentKind, bool) {
+	return KindByObjectType(r.ObjectType)
+}
+
+// KindByObjectType returns the document kind SAP identifies by objectType.
+func KindByObjectType(objectType int) (DocumentKind, bool) {
+	for _, kind := range []DocumentKind{
+		KindQuotation, KindOrder, KindDeliveryNote, KindReturn, KindInvoice,
+		KindPurchaseRequest, KindPurchaseQuotation, KindPurchaseOrder,
+		KindPurchaseDeliveryNote, KindPurchaseReturn, KindPurchaseInvoice,
+	} {
+		if kind.ObjectType == objectType {
+			return kind, true
+		}
+	}
+
+	return DocumentKind{}, false
+}
+
+// IsLineOpen reports whether the line can still be copied to a target document.
+func (l *DocumentLine) IsLineOpen() bool {
+	return l.LineStatus != BoStatusClose
+}
+
+// Fulfilment summarizes the line. Closed lines have nothing open, whatever was left over.
+func (l *DocumentLine) Fulfilment() LineFulfilment {
+	f := LineFulfilment{
+		LineNum:   l.LineNum,
+		ItemCode:  l.ItemCode,
+		Quantity:  l.Quantity,
+		Fulfilled: l.Quantity.Sub(l.RemainingOpenQuantity),
+		Closed:    !l.IsLineOpen(),
+	}
+
+	if !f.Closed {
+		f.Open = l.RemainingOpenQuantity
+		f.OpenAmount = l.OpenAmount
+	}
+
+	return f
+}
+
+// Lines returns the shared part of every line of the document.
+func (d *Document) Lines() []DocumentLine {
+	return d.DocumentLines
+}
+
+func (d *DeliveryNote) Lines() []DocumentLine {
+	return baseLines(d.DocumentLines, func(l DeliveryNoteLine) DocumentLine { return l.DocumentLine })
+}
+
+func (d *PurchaseOrder) Lines() []DocumentLine {
+	return baseLines(d.DocumentLines, func(l PurchaseOrderLine) DocumentLine { return l.DocumentLine })
+}
+
+func (d *PurchaseDeliveryNote) Lines() []DocumentLine {
+	return baseLines(d.DocumentLines, func(l PurchaseDeliveryNoteLine) DocumentLine { return l.DocumentLine })
+}
+
+func baseLines[L any](lines []L, base func(L) DocumentLine) []DocumentLine {
+	res := make([]DocumentLine, 0, len(lines))
+	for _, line := range lines {
+		res = append(res, base(line))
+	}
+
+	return res
+}
+
+// Fulfilment summarizes the document line by line.
+func (d *Document) Fulfilment() DocumentFulfilment {
+	return documentFulfilment(d.DocEntry, d.Lines())
+}
+
+func (d *DeliveryNote) Fulfilment() DocumentFulfilment {
+	return documentFulfilment(d.DocEntry, d.Lines())
+}
+
+func (d *PurchaseOrder) Fulfilment() DocumentFulfilment {
+	return documentFulfilment(d.DocEntry, d.Lines())
+}
+
+func (d *PurchaseDeliveryNote) Fulfilment() DocumentFulfilment {
+	return documentFulfilment(d.DocEntry, d.Lines())
+}
+
+func documentFulfilment(docEntry int, lines []DocumentLine) DocumentFulfilment {
+	f := DocumentFulfilment{DocEntry: docEntry, Lines: make([]LineFulfilment, 0, len(lines))}
+
+	for i := range lines {
+		line := lines[i].Fulfilment()
+
+		f.Quantity = f.Quantity.Add(line.Quantity)
+		f.Fulfilled = f.Fulfilled.Add(line.Fulfilled)
+		f.Open = f.Open.Add(line.Open)
+		f.OpenAmount = f.OpenAmount.Add(line.OpenAmount)
+		f.Lines = append(f.Lines, line)
+	}
+
+	return f
+}
+
+// TargetDocuments lists the distinct documents the lines were last copied to, in line order.
+func (d *Document) TargetDocuments() []DocumentReference {
+	return targetDocuments(d.Lines())
+}
+
+func (d *DeliveryNote) TargetDocuments() []DocumentReference {
+	return targetDocuments(d.Lines())
+}
+
+func (d *PurchaseOrder) TargetDocuments() []DocumentReference {
+	return targetDocuments(d.Lines())
+}
+
+func (d *PurchaseDeliveryNote) TargetDocuments() []DocumentReference {
+	return targetDocuments(d.Lines())
+}
+
+func targetDocuments(lines []DocumentLine) []DocumentReference {
+	var targets []DocumentReference
+
+	seen := map[DocumentReference]bool{}
+	for _, line := range lines {
+		if line.TargetType <= 0 || line.TargetEntry == 0 {
+			continue
+		}
+
+		ref := DocumentReference{ObjectType: line.TargetType, DocEntry: line.TargetEntry}
+		if !seen[ref] {
+			seen[ref] = true
+			targets = append(targets, ref)
+		}
+	}
+
+	return targets
+}
+
+// GetTargetDocuments fetches the documents refs point to, e.g. the result of TargetDocuments.
+// References to document types gosap doesn't know are skipped.
+func (s *Session) GetTargetDocuments(cfg Config, refs []DocumentReference) (_ []Document, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	var targets []Document
+
+	for _, ref := range refs {
+		kind, ok := ref.Kind()
+		if !ok {
+			continue
+		}
+
+		target, err := retrieveDocument[Document](s, cfg.GetDocumentEndpoint(kind, ref.DocEntry))
+		if err != nil {
+			return targets, err
+		}
+
+		targets = append(targets, *target)
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,48 @@
+package gosap_test
+
+import (
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentFulfilment(t *testing.T) {
+	t.Parallel()
+
+	order := gosap.PurchaseOrder{
+		DocumentHeader: gosap.DocumentHeader{DocEntry: 42},
+		DocumentLines: []gosap.PurchaseOrderLine{
+			{DocumentLine: gosap.DocumentLine{
+				LineNum: 0, Quantity: "10", RemainingOpenQuantity: "4", OpenAmount: "40", LineStatus: gosap.BoStatusOpen,
+				TargetType: 20, TargetEntry: 7,
+			}},
+			{DocumentLine: gosap.DocumentLine{
+				LineNum: 1, Quantity: "5", RemainingOpenQuantity: "2", LineStatus: gosap.BoStatusClose,
+				TargetType: 20, TargetEntry: 7,
+			}},
+			{DocumentLine: gosap.DocumentLine{
+				LineNum: 2, Quantity: "8", RemainingOpenQuantity: "8", OpenAmount: "80", LineStatus: gosap.BoStatusOpen,
+				TargetType: -1,
+			}},
+		},
+	}
+
+	f := order.Fulfilment()
+	assert.Equal(t, gosap.Decimal("23"), f.Quantity)
+	assert.Equal(t, gosap.Decimal("9"), f.Fulfilled)
+	assert.Equal(t, gosap.Decimal("12"), f.Open)
+	assert.Equal(t, gosap.Decimal("120"), f.OpenAmount)
+	assert.False(t, f.Complete())
+	require.Len(t, f.Lines, 3)
+	assert.True(t, f.Lines[1].Closed)
+	assert.Zero(t, f.Lines[1].Open)
+
+	targets := order.TargetDocuments()
+	require.Equal(t, []gosap.DocumentReference{{ObjectType: 20, DocEntry: 7}}, targets)
+
+	kind, ok := targets[0].Kind()
+	assert.True(t, ok)
+	assert.Equal(t, gosap.KindPurchaseDeliveryNote, kind)
+}
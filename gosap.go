@@ -1,78 +1,286 @@
 package gosap
 
 import (
-	"crypto/tls"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Session struct {
 	B1Session string
 	RouteID   string
+	// mu guards the cookies and timeout against Relogin.
+	mu sync.RWMutex
+	// timeout is the idle time after which the Service Layer ends the session, 0 if unknown.
+	timeout time.Duration
+	// lastUsed is when the Service Layer last answered the session, in Unix nanoseconds.
+	lastUsed atomic.Int64
+	// unauthorized is set once the Service Layer answered 401 since the last login.
+	unauthorized atomic.Bool
+	// etags holds the last ETag read per entity URL, sent back as If-Match.
+	etags etagCache
+	// client is the one the session logged in with, nil for sessions built by hand.
+	client         *http.Client
+	tracerProvider trace.TracerProvider
+	// ctx is the context requests are sent with, set by WithContext.
+	ctx context.Context
+	// base is the session a WithContext one was derived from, holding the shared state.
+	base *Session
 }
 
 func Authenticate(cfg Config) (*Session, error) {
-	loginPayload, err := cfg.LoginPayload()
-	if err != nil {
+	return AuthenticateContext(context.Background(), cfg)
+}
+
+// AuthenticateContext logs in with ctx, e.g. to trace the login as part of the caller's work.
+func AuthenticateContext(ctx context.Context, cfg Config) (*Session, error) {
+	session := &Session{client: cfg.httpClient(), tracerProvider: cfg.TracerProvider}
+
+	if err := session.login(WithOperation(ctx, "Authenticate"), cfg); err != nil {
 		return nil, err
 	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	return session, nil
+}
+
+// Relogin logs the session in again, e.g. once the Service Layer expired it, keeping its ETags.
+// Sessions derived from it with WithContext send the new cookies too.
+func (s *Session) Relogin(cfg Config) error {
+	if err := s.root().login(WithOperation(s.context(), "Relogin"), cfg); err != nil {
+		return fmt.Errorf("could not log in again due to %w", err)
+	}
+
+	return nil
+}
+
+// Expired reports whether the Service Layer ended the session: it answered 401 since the last
+// login, or the session stayed idle longer than the timeout the Service Layer announced.
+func (s *Session) Expired() bool {
+	root := s.root()
+	if root.unauthorized.Load() {
+		return true
 	}
-	client := &http.Client{Transport: tr}
 
-	resp, err := client.Post(cfg.LoginEndpoint(), "application/json", strings.NewReader(loginPayload))
+	root.mu.RLock()
+	timeout := root.timeout
+	root.mu.RUnlock()
+
+	lastUsed := root.lastUsed.Load()
+
+	return timeout > 0 && lastUsed > 0 && time.Since(time.Unix(0, lastUsed)) >= timeout
+}
+
+// login posts the credentials of cfg and keeps the session cookies set by the Service Layer.
+func (s *Session) login(ctx context.Context, cfg Config) error {
+	loginPayload, err := cfg.LoginPayload()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
-	content, err := io.ReadAll(resp.Body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.LoginEndpoint(), strings.NewReader(loginPayload))
 	if err != nil {
-		return nil, fmt.Errorf("could not read response body due to %s", err)
+		return err
 	}
 
-	statusOK := resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
-	if !statusOK {
-		return nil, fmt.Errorf("request to SAP API (%s) was not successful due to %s - %s", cfg.LoginEndpoint(), resp.Status, string(content))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.client
+	if client == nil {
+		client = cfg.httpClient()
+	}
+
+	req, span := s.startRequest(req)
+
+	resp, content, err := send(client, req)
+	span.end(resp, content, err)
+
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("request to SAP API (%s) was not successful due to %s - %s", cfg.LoginEndpoint(), resp.Status, string(content))
+		}
+
+		return err
+	}
+
+	var login struct {
+		SessionTimeout int
 	}
 
-	cookies := resp.Cookies()
-	session := Session{}
+	// The body only tells the timeout, the session works without it.
+	_ = json.Unmarshal(content, &login)
 
-	for _, cookie := range cookies {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cookie := range resp.Cookies() {
 		if cookie.Name == "B1SESSION" {
-			session.B1Session = cookie.Value
+			s.B1Session = cookie.Value
 		}
 
 		if cookie.Name == "ROUTEID" {
-			session.RouteID = cookie.Value
+			s.RouteID = cookie.Value
 		}
 	}
 
-	return &session, nil
+	s.timeout = time.Duration(login.SessionTimeout) * time.Minute
+	s.lastUsed.Store(time.Now().UnixNano())
+	s.unauthorized.Store(false)
+
+	return nil
+}
+
+// WithContext returns the session sending its requests with ctx, so they are cancelled with it
+// and traced as part of the caller's span. The returned session shares its state with s.
+//
+//	order, err := session.WithContext(ctx).GetPurchaseOrder(cfg, "12")
+func (s *Session) WithContext(ctx context.Context) *Session {
+	root := s.root()
+
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+
+	return &Session{B1Session: root.B1Session, RouteID: root.RouteID, ctx: ctx, base: root}
+}
+
+// Context returns the context the session sends its requests with.
+func (s *Session) Context() context.Context {
+	return s.context()
+}
+
+func (s *Session) context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+
+	return context.Background()
+}
+
+// root returns the session holding the state shared by the sessions derived with WithContext.
+func (s *Session) root() *Session {
+	if s.base != nil {
+		return s.base
+	}
+
+	return s
+}
+
+// withContext moves req to the context of the session, keeping the operation and page gosap
+// recorded on it.
+func (s *Session) withContext(req *http.Request) *http.Request {
+	if s.ctx == nil {
+		return req
+	}
+
+	ctx := s.ctx
+	if op := Operation(req.Context()); op != "" && Operation(ctx) == "" {
+		ctx = WithOperation(ctx, op)
+	}
+
+	if page := PageOf(req); page > 0 {
+		ctx = context.WithValue(ctx, pageKey{}, page)
+	}
+
+	return req.WithContext(ctx)
+}
+
+// trackExpiry records the answer of the Service Layer for Expired.
+func (s *Session) trackExpiry(resp *http.Response) {
+	root := s.root()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		root.unauthorized.Store(true)
+
+		return
+	}
+
+	root.lastUsed.Store(time.Now().UnixNano())
+}
+
+// Logout ends the session on the Service Layer, freeing its license seat.
+func (s *Session) Logout(cfg Config) error {
+	req, err := http.NewRequest(http.MethodPost, cfg.LogoutEndpoint(), nil)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := s.Do(req); err != nil {
+		return fmt.Errorf("could not log out due to %w", err)
+	}
+
+	return nil
 }
 
 func (s *Session) setSessionCookies(req *http.Request) {
-	req.AddCookie(&http.Cookie{Name: "B1SESSION", Value: s.B1Session})
-	req.AddCookie(&http.Cookie{Name: "ROUTEID", Value: s.RouteID})
+	root := s.root()
+
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+
+	req.AddCookie(&http.Cookie{Name: "B1SESSION", Value: root.B1Session})
+	req.AddCookie(&http.Cookie{Name: "ROUTEID", Value: root.RouteID})
 }
 
-// Do sends the request and returns the response.
-// Caller should close Body of response after reading it.
+// Do sends the request and returns the response, with the body read. The annotations of v2
+// bodies are renamed to their v1 names, e.g. @odata.nextLink to odata.nextLink.
 func (s *Session) Do(req *http.Request) (*http.Response, []byte, error) {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	return s.do(req, true)
+}
+
+// do sends req like Do. Unless conditional, PATCH and DELETE requests are sent without the
+// If-Match of the entity.
+func (s *Session) do(req *http.Request, conditional bool) (*http.Response, []byte, error) {
+	client := s.root().client
+	if client == nil {
+		client = defaultClient()
 	}
-	client := &http.Client{Transport: tr}
 
+	req = withOperation(s.withContext(req))
 	s.setSessionCookies(req)
+	s.setIfMatch(req, conditional)
 	req.Header.Set("Content-Type", "application/json")
 
+	req, span := s.startRequest(req)
+
+	resp, content, err := send(client, req)
+	span.end(resp, content, err)
+
+	if resp == nil {
+		return nil, content, err
+	}
+
+	s.trackExpiry(resp)
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		s.root().etags.delete(etagKey(req.URL))
+
+		return nil, content, fmt.Errorf("request to SAP API (%s) was not successful due to %w - %s", req.URL, ErrConflict, string(content))
+	}
+
+	if err != nil {
+		return nil, content, fmt.Errorf("request to SAP API (%s) was not successful due to %s - %s", req.URL, resp.Status, string(content))
+	}
+
+	s.trackETag(req, resp)
+
+	return resp, normalizeAnnotations(content), nil
+}
+
+// errStatus marks responses with a status other than 2xx in send.
+var errStatus = errors.New("unsuccessful status")
+
+// send sends req and reads the response. The response is returned whenever one was read, with
+// errStatus when its status isn't 2xx.
+func send(client *http.Client, req *http.Request) (*http.Response, []byte, error) {
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, []byte{}, err
@@ -87,14 +295,16 @@ func (s *Session) Do(req *http.Request) (*http.Response, []byte, error) {
 
 	statusOK := resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
 	if !statusOK {
-		return nil, content, fmt.Errorf("request to SAP API (%s) was not successful due to %s - %s", req.URL, resp.Status, string(content))
+		return resp, content, fmt.Errorf("%w %s", errStatus, resp.Status)
 	}
 
 	return resp, content, nil
 }
 
-func (s *Session) GetItem(cfg Config, id string) (*Item, error) {
-	req, err := http.NewRequest(http.MethodGet, cfg.GetItemEndpoint(id), nil)
+// GetItem fetches an item with the given fields, or DefaultItemFields when none are given.
+// Pass "*" to fetch every field.
+func (s *Session) GetItem(cfg Config, id string, fields ...string) (*Item, error) {
+	req, err := http.NewRequest(http.MethodGet, cfg.GetItemEndpoint(id, fields...), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +322,8 @@ func (s *Session) GetItem(cfg Config, id string) (*Item, error) {
 	return &item, nil
 }
 
-func (s *Session) getItems(cfg Config, endpoint string) (*Items, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getItems(cfg Config, endpoint string, page int) (*Items, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +339,7 @@ func (s *Session) getItems(cfg Config, endpoint string) (*Items, error) {
 	}
 
 	if items.NextLink != nil && *items.NextLink != "" {
-		next, err := s.getItems(cfg, cfg.BuildEndpoint(*items.NextLink))
+		next, err := s.getItems(cfg, cfg.BuildEndpoint(*items.NextLink), page+1)
 		if err != nil {
 			return &items, err
 		}
@@ -140,16 +350,167 @@ func (s *Session) getItems(cfg Config, endpoint string) (*Items, error) {
 	return &items, nil
 }
 
-func (s *Session) GetItems(cfg Config) (*Items, error) {
-	return s.getItems(cfg, cfg.GetItemsEndpoint())
+// GetItems fetches all items with the given fields, or DefaultItemFields when none are given.
+// Pass "*" to fetch every field.
+func (s *Session) GetItems(cfg Config, fields ...string) (_ *Items, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getItems(cfg, cfg.GetItemsEndpoint(fields...), 1)
+}
+
+// CreateItem creates an item master record and returns it as stored by SAP.
+func (s *Session) CreateItem(cfg Config, item Item) (*Item, error) {
+	created, err := createDocument[Item](s, cfg.CreateItemEndpoint(), item)
+	if err != nil {
+		return nil, fmt.Errorf("could not create item due to %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateItem sends the non-empty fields of updates to the item.
+func (s *Session) UpdateItem(cfg Config, id string, updates Item) error {
+	if err := updateDocument(s, cfg.UpdateItemEndpoint(id), updates); err != nil {
+		return fmt.Errorf("could not update item due to %w", err)
+	}
+
+	return nil
+}
+
+// PatchItem sends only the properties of patch to the item. Unlike UpdateItem, it can set a
+// property to 0 or clear it.
+func (s *Session) PatchItem(cfg Config, id string, patch *Patch) error {
+	if err := updateDocument(s, cfg.UpdateItemEndpoint(id), patch); err != nil {
+		return fmt.Errorf("could not patch item %s due to %w", id, err)
+	}
+
+	return nil
+}
+
+// DeactivateItem marks the item inactive so it can no longer be used in new documents.
+func (s *Session) DeactivateItem(cfg Config, id string) error {
+	return s.UpdateItem(cfg, id, Item{Valid: BoNo, Frozen: BoYes})
+}
+
+func (s *Session) CancelItem(cfg Config, id string) error {
+	return s.postAction(cfg.CancelItemEndpoint(id))
+}
+
+func (s *Session) DeleteItem(cfg Config, id string) error {
+	req, err := http.NewRequest(http.MethodDelete, cfg.UpdateItemEndpoint(id), nil)
+	if err != nil {
+		return fmt.Errorf("could not create delete request due to %w", err)
+	}
+
+	_, _, err = s.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete item due to %w", err)
+	}
+
+	return nil
+}
+
+// GetItemPrices fetches the prices of an item in every price list.
+func (s *Session) GetItemPrices(cfg Config, id string) ([]ItemPrice, error) {
+	item, err := s.GetItem(cfg, id, "ItemCode", "ItemPrices")
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ItemPrices, nil
+}
+
+// UpdateItemPrices sets the prices of an item in the price lists present in prices, including
+// prices of 0. Price lists missing from prices are left untouched.
+func (s *Session) UpdateItemPrices(cfg Config, id string, prices []ItemPrice) error {
+	lines := make([]*Patch, 0, len(prices))
+	for _, price := range prices {
+		line := NewPatch().Set("PriceList", price.PriceList).Set("Price", price.Price)
+		if price.Currency != "" {
+			line.Set("Currency", price.Currency)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return s.PatchItem(cfg, id, NewPatch().Set("ItemPrices", lines))
+}
+
+func (s *Session) GetPriceLists(cfg Config) ([]PriceList, error) {
+	return retrieveDocuments[PriceList](s, cfg, cfg.GetPriceListsEndpoint())
+}
+
+func (s *Session) GetPriceList(cfg Config, id int) (*PriceList, error) {
+	return retrieveDocument[PriceList](s, cfg.GetPriceListEndpoint(id))
+}
+
+// UpdatePriceList sends the non-empty fields of updates to the price list header.
+func (s *Session) UpdatePriceList(cfg Config, id int, updates PriceList) error {
+	if err := updateDocument(s, cfg.GetPriceListEndpoint(id), updates); err != nil {
+		return fmt.Errorf("could not update price list due to %w", err)
+	}
+
+	return nil
+}
+
+// PatchPriceList sends only the properties of patch to the price list. Unlike UpdatePriceList,
+// it can set a property to 0 or clear it.
+func (s *Session) PatchPriceList(cfg Config, id int, patch *Patch) error {
+	if err := updateDocument(s, cfg.GetPriceListEndpoint(id), patch); err != nil {
+		return fmt.Errorf("could not patch price list %d due to %w", id, err)
+	}
+
+	return nil
 }
 
-func (s *Session) GetSuppliers(cfg Config) (*Suppliers, error) {
-	return s.getSuppliers(cfg, cfg.GetSuppliersEndpoint())
+func (s *Session) GetSpecialPrices(cfg Config) ([]SpecialPrice, error) {
+	return retrieveDocuments[SpecialPrice](s, cfg, cfg.GetSpecialPricesEndpoint())
 }
 
-func (s *Session) getSuppliers(cfg Config, endpoint string) (*Suppliers, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) GetSpecialPrice(cfg Config, itemCode, cardCode string) (*SpecialPrice, error) {
+	return retrieveDocument[SpecialPrice](s, cfg.GetSpecialPriceEndpoint(itemCode, cardCode))
+}
+
+func (s *Session) CreateSpecialPrice(cfg Config, price SpecialPrice) (*SpecialPrice, error) {
+	created, err := createDocument[SpecialPrice](s, cfg.GetSpecialPricesEndpoint(), price)
+	if err != nil {
+		return nil, fmt.Errorf("could not create special price due to %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateSpecialPrice sends the non-empty fields of updates to the special price.
+func (s *Session) UpdateSpecialPrice(cfg Config, itemCode, cardCode string, updates SpecialPrice) error {
+	if err := updateDocument(s, cfg.GetSpecialPriceEndpoint(itemCode, cardCode), updates); err != nil {
+		return fmt.Errorf("could not update special price due to %w", err)
+	}
+
+	return nil
+}
+
+// PatchSpecialPrice sends only the properties of patch to the special price. Unlike
+// UpdateSpecialPrice, it can set a property to 0 or clear it.
+func (s *Session) PatchSpecialPrice(cfg Config, itemCode, cardCode string, patch *Patch) error {
+	if err := updateDocument(s, cfg.GetSpecialPriceEndpoint(itemCode, cardCode), patch); err != nil {
+		return fmt.Errorf("could not patch special price due to %w", err)
+	}
+
+	return nil
+}
+
+// GetSuppliers lists the suppliers with the given fields, or DefaultBusinessPartnerFields when
+// none are given.
+func (s *Session) GetSuppliers(cfg Config, fields ...string) (_ *Suppliers, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getSuppliers(cfg, cfg.GetSuppliersEndpoint(fields...), 1)
+}
+
+func (s *Session) getSuppliers(cfg Config, endpoint string, page int) (*Suppliers, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +526,7 @@ func (s *Session) getSuppliers(cfg Config, endpoint string) (*Suppliers, error)
 	}
 
 	if suppliers.NextLink != nil && *suppliers.NextLink != "" {
-		next, err := s.getSuppliers(cfg, cfg.BuildEndpoint(*suppliers.NextLink))
+		next, err := s.getSuppliers(cfg, cfg.BuildEndpoint(*suppliers.NextLink), page+1)
 		if err != nil {
 			return &suppliers, err
 		}
@@ -176,12 +537,17 @@ func (s *Session) getSuppliers(cfg Config, endpoint string) (*Suppliers, error)
 	return &suppliers, nil
 }
 
-func (s *Session) GetClients(cfg Config) (*Clients, error) {
-	return s.getClients(cfg, cfg.GetClientsEndpoint())
+// GetClients lists the customers with the given fields, or DefaultBusinessPartnerFields when
+// none are given.
+func (s *Session) GetClients(cfg Config, fields ...string) (_ *Clients, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getClients(cfg, cfg.GetClientsEndpoint(fields...), 1)
 }
 
-func (s *Session) getClients(cfg Config, endpoint string) (*Clients, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getClients(cfg Config, endpoint string, page int) (*Clients, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +563,7 @@ func (s *Session) getClients(cfg Config, endpoint string) (*Clients, error) {
 	}
 
 	if clients.NextLink != nil && *clients.NextLink != "" {
-		next, err := s.getSuppliers(cfg, cfg.BuildEndpoint(*clients.NextLink))
+		next, err := s.getClients(cfg, cfg.BuildEndpoint(*clients.NextLink), page+1)
 		if err != nil {
 			return &clients, err
 		}
@@ -208,12 +574,75 @@ func (s *Session) getClients(cfg Config, endpoint string) (*Clients, error) {
 	return &clients, nil
 }
 
-func (s *Session) GetDeliveryNotes(cfg Config) (*DeliveryNotes, error) {
-	return s.getDeliveryNotes(cfg, cfg.GetDeliveryNotesEndpoint())
+// GetLeads lists the leads with the given fields, or DefaultBusinessPartnerFields when none
+// are given.
+func (s *Session) GetLeads(cfg Config, fields ...string) (*Leads, error) {
+	leads, err := retrieveDocuments[Lead](s, cfg, cfg.GetLeadsEndpoint(fields...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Leads{Value: leads}, nil
+}
+
+func (s *Session) GetBusinessPartner(cfg Config, cardCode string) (*BusinessPartner, error) {
+	return retrieveDocument[BusinessPartner](s, cfg.GetBusinessPartnerEndpoint(cardCode))
+}
+
+// CreateBusinessPartner creates a business partner together with its addresses and contact
+// employees and returns it as stored by SAP.
+func (s *Session) CreateBusinessPartner(cfg Config, partner BusinessPartner) (*BusinessPartner, error) {
+	created, err := createDocument[BusinessPartner](s, cfg.GetBusinessPartnersEndpoint(), partner)
+	if err != nil {
+		return nil, fmt.Errorf("could not create business partner due to %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateBusinessPartner sends the non-empty fields of updates. Addresses and contact
+// employees are merged with the existing ones by SAP.
+func (s *Session) UpdateBusinessPartner(cfg Config, cardCode string, updates BusinessPartner) error {
+	if err := updateDocument(s, cfg.GetBusinessPartnerEndpoint(cardCode), updates); err != nil {
+		return fmt.Errorf("could not update business partner due to %w", err)
+	}
+
+	return nil
+}
+
+// PatchBusinessPartner sends only the properties of patch to the business partner. Unlike
+// UpdateBusinessPartner, it can set a property to 0 or clear it.
+func (s *Session) PatchBusinessPartner(cfg Config, cardCode string, patch *Patch) error {
+	if err := updateDocument(s, cfg.GetBusinessPartnerEndpoint(cardCode), patch); err != nil {
+		return fmt.Errorf("could not patch business partner %s due to %w", cardCode, err)
+	}
+
+	return nil
+}
+
+func (s *Session) DeleteBusinessPartner(cfg Config, cardCode string) error {
+	req, err := http.NewRequest(http.MethodDelete, cfg.GetBusinessPartnerEndpoint(cardCode), nil)
+	if err != nil {
+		return fmt.Errorf("could not create delete request due to %w", err)
+	}
+
+	_, _, err = s.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete business partner due to %w", err)
+	}
+
+	return nil
+}
+
+func (s *Session) GetDeliveryNotes(cfg Config) (_ *DeliveryNotes, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getDeliveryNotes(cfg, cfg.GetDeliveryNotesEndpoint(), 1)
 }
 
-func (s *Session) getDeliveryNotes(cfg Config, endpoint string) (*DeliveryNotes, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getDeliveryNotes(cfg Config, endpoint string, page int) (*DeliveryNotes, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +658,7 @@ func (s *Session) getDeliveryNotes(cfg Config, endpoint string) (*DeliveryNotes,
 	}
 
 	if notes.NextLink != nil && *notes.NextLink != "" {
-		next, err := s.getDeliveryNotes(cfg, cfg.BuildEndpoint(*notes.NextLink))
+		next, err := s.getDeliveryNotes(cfg, cfg.BuildEndpoint(*notes.NextLink), page+1)
 		if err != nil {
 			return &notes, err
 		}
@@ -259,7 +688,9 @@ func (s *Session) GetDeliveryNote(cfg Config, id string) (*DeliveryNote, error)
 	return &note, nil
 }
 
-func (s *Session) changeDeliveryNote(endpoint string) error {
+// postAction runs a bound action of an entity, such as Close, Cancel or Reopen, posting it
+// without a body.
+func (s *Session) postAction(endpoint string) error {
 	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
 	if err != nil {
 		return err
@@ -271,23 +702,26 @@ func (s *Session) changeDeliveryNote(endpoint string) error {
 }
 
 func (s *Session) RopenDeliveryNote(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.ReopenDeliveryNoteEndpoint(id))
+	return s.postAction(cfg.ReopenDeliveryNoteEndpoint(id))
 }
 
 func (s *Session) CloseDeliveryNote(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.CloseDeliveryNoteEndpoint(id))
+	return s.postAction(cfg.CloseDeliveryNoteEndpoint(id))
 }
 
 func (s *Session) CancelDeliveryNote(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.CancelDeliveryNoteEndpoint(id))
+	return s.postAction(cfg.CancelDeliveryNoteEndpoint(id))
 }
 
-func (s *Session) GetPurchaseOrders(cfg Config) (*PurchaseOrders, error) {
-	return s.getPurchaseOrders(cfg, cfg.GetPurchaseOrdersEndpoint())
+func (s *Session) GetPurchaseOrders(cfg Config) (_ *PurchaseOrders, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getPurchaseOrders(cfg, cfg.GetPurchaseOrdersEndpoint(), 1)
 }
 
-func (s *Session) getPurchaseOrders(cfg Config, endpoint string) (*PurchaseOrders, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getPurchaseOrders(cfg Config, endpoint string, page int) (*PurchaseOrders, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -303,7 +737,7 @@ func (s *Session) getPurchaseOrders(cfg Config, endpoint string) (*PurchaseOrder
 	}
 
 	if notes.NextLink != nil && *notes.NextLink != "" {
-		next, err := s.getPurchaseOrders(cfg, cfg.BuildEndpoint(*notes.NextLink))
+		next, err := s.getPurchaseOrders(cfg, cfg.BuildEndpoint(*notes.NextLink), page+1)
 		if err != nil {
 			return &notes, err
 		}
@@ -334,23 +768,26 @@ func (s *Session) GetPurchaseOrder(cfg Config, id string) (*PurchaseOrder, error
 }
 
 func (s *Session) ReopenPurchaseOrder(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.ReopenPurchaseOrderEndpoint(id))
+	return s.postAction(cfg.ReopenPurchaseOrderEndpoint(id))
 }
 
 func (s *Session) ClosePurchaseOrder(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.ClosePurchaseOrderEndpoint(id))
+	return s.postAction(cfg.ClosePurchaseOrderEndpoint(id))
 }
 
 func (s *Session) CancelPurchaseOrder(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.CancelPurchaseOrderEndpoint(id))
+	return s.postAction(cfg.CancelPurchaseOrderEndpoint(id))
 }
 
-func (s *Session) GetPurchaseDeliveryNotes(cfg Config) (*PurchaseDeliveryNotes, error) {
-	return s.getPurchaseDeliveryNotes(cfg, cfg.GetPurchaseDeliveryNotesEndpoint())
+func (s *Session) GetPurchaseDeliveryNotes(cfg Config) (_ *PurchaseDeliveryNotes, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getPurchaseDeliveryNotes(cfg, cfg.GetPurchaseDeliveryNotesEndpoint(), 1)
 }
 
-func (s *Session) getPurchaseDeliveryNotes(cfg Config, endpoint string) (*PurchaseDeliveryNotes, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getPurchaseDeliveryNotes(cfg Config, endpoint string, page int) (*PurchaseDeliveryNotes, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -366,7 +803,7 @@ func (s *Session) getPurchaseDeliveryNotes(cfg Config, endpoint string) (*Purcha
 	}
 
 	if notes.NextLink != nil && *notes.NextLink != "" {
-		next, err := s.getPurchaseDeliveryNotes(cfg, cfg.BuildEndpoint(*notes.NextLink))
+		next, err := s.getPurchaseDeliveryNotes(cfg, cfg.BuildEndpoint(*notes.NextLink), page+1)
 		if err != nil {
 			return &notes, err
 		}
@@ -382,15 +819,15 @@ func (s *Session) GetPurchaseDeliveryNote(cfg Config, id string) (*PurchaseDeliv
 }
 
 func (s *Session) ReopenPurchaseDeliveryNote(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.ReopenPurchaseDeliveryNoteEndpoint(id))
+	return s.postAction(cfg.ReopenPurchaseDeliveryNoteEndpoint(id))
 }
 
 func (s *Session) ClosePurchaseDeliveryNote(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.ClosePurchaseDeliveryNoteEndpoint(id))
+	return s.postAction(cfg.ClosePurchaseDeliveryNoteEndpoint(id))
 }
 
 func (s *Session) CancelPurchaseDeliveryNote(cfg Config, id string) error {
-	return s.changeDeliveryNote(cfg.CancelPurchaseDeliveryNoteEndpoint(id))
+	return s.postAction(cfg.CancelPurchaseDeliveryNoteEndpoint(id))
 }
 
 func (s *Session) CreatePurchaseDeliveryNote(cfg Config, note PurchaseDeliveryNote) (bool, error) {
@@ -416,7 +853,12 @@ func (s *Session) CreatePurchaseDeliveryNote(cfg Config, note PurchaseDeliveryNo
 // retrieveDocument pulls a document type from an SAP endpoint. The type of Unmarshal needs to
 // be specified when calling the function.
 func retrieveDocument[T any](s *Session, endpoint string) (*T, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	return retrievePage[T](s, endpoint, 0)
+}
+
+// retrievePage pulls the page of a collection at endpoint, counting pages from 1.
+func retrievePage[T any](s *Session, endpoint string, page int) (*T, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -434,6 +876,81 @@ func retrieveDocument[T any](s *Session, endpoint string) (*T, error) {
 	return &doc, nil
 }
 
+// retrieveDocuments pulls every page of an entity collection, following odata.nextLink until
+// the Service Layer stops returning one.
+func retrieveDocuments[T any](s *Session, cfg Config, endpoint string) (docs []T, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	for index := 1; endpoint != ""; index++ {
+		page, err := retrievePage[collection[T]](s, endpoint, index)
+		if err != nil {
+			return docs, err
+		}
+
+		docs = append(docs, page.Value...)
+
+		endpoint = ""
+		if page.NextLink != nil && *page.NextLink != "" {
+			endpoint = cfg.BuildEndpoint(*page.NextLink)
+		}
+	}
+
+	return docs, nil
+}
+
+// createDocument posts doc to endpoint and loads the entity the Service Layer returns.
+func createDocument[T any](s *Session, endpoint string, doc any) (*T, error) {
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	_, content, err := s.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var created T
+	if err := json.Unmarshal(content, &created); err != nil {
+		return nil, fmt.Errorf("could not load json response due to %s", err)
+	}
+
+	return &created, nil
+}
+
+// updateDocument patches the entity at endpoint with the JSON encoding of updates.
+func updateDocument(s *Session, endpoint string, updates any) error {
+	payload, err := json.Marshal(updates)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	conditional := true
+
+	if patch, ok := updates.(*Patch); ok {
+		if patch.replaceCollections {
+			req.Header.Set("B1S-ReplaceCollectionsOnPatch", "true")
+		}
+
+		conditional = !patch.unconditional
+	}
+
+	_, _, err = s.do(req, conditional)
+
+	return err
+}
+
 func (s *Session) GetInventoryCounting(cfg Config, id int) (*InventoryCounting, error) {
 	url := cfg.GetInventoryCountingEndpoint(id)
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -456,12 +973,15 @@ func (s *Session) GetInventoryCounting(cfg Config, id int) (*InventoryCounting,
 	return &inventoryCounting, nil
 }
 
-func (s *Session) GetInventoryCountings(cfg Config) ([]InventoryCounting, error) {
-	return s.getInventoryCountings(cfg, cfg.GetInventoryCountingsEndpoint())
+func (s *Session) GetInventoryCountings(cfg Config) (_ []InventoryCounting, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getInventoryCountings(cfg, cfg.GetInventoryCountingsEndpoint(), 1)
 }
 
-func (s *Session) getInventoryCountings(cfg Config, endpoint string) ([]InventoryCounting, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getInventoryCountings(cfg Config, endpoint string, page int) ([]InventoryCounting, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, err
 	}
@@ -478,7 +998,7 @@ func (s *Session) getInventoryCountings(cfg Config, endpoint string) ([]Inventor
 	}
 
 	if response.NextLink != nil && *response.NextLink != "" {
-		next, err := s.getInventoryCountings(cfg, cfg.BuildEndpoint(*response.NextLink))
+		next, err := s.getInventoryCountings(cfg, cfg.BuildEndpoint(*response.NextLink), page+1)
 		if err != nil {
 			return response.Value, err
 		}
@@ -502,12 +1022,14 @@ func (s *Session) CreateInventoryCounting(cfg Config, counting InventoryCounting
 
 	_, _, err = s.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("could not read response body content due to %s", err)
+		return false, fmt.Errorf("could not read response body content due to %w", err)
 	}
 
 	return true, nil
 }
 
+// UpdateInventoryCounting sends the non-empty fields of updates to the counting. Use
+// PatchInventoryCounting to set a counted quantity to 0.
 func (s *Session) UpdateInventoryCounting(cfg Config, id int, updates InventoryCounting) (bool, error) {
 	payload, err := json.Marshal(updates)
 	if err != nil {
@@ -522,7 +1044,7 @@ func (s *Session) UpdateInventoryCounting(cfg Config, id int, updates InventoryC
 
 	_, _, err = s.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("could not read response body content due to %s", err)
+		return false, fmt.Errorf("could not read response body content due to %w", err)
 	}
 
 	return true, nil
@@ -537,7 +1059,7 @@ func (s *Session) DeleteInventoryCounting(cfg Config, id int) (bool, error) {
 
 	_, _, err = s.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("could not read response body content due to %s", err)
+		return false, fmt.Errorf("could not read response body content due to %w", err)
 	}
 
 	return true, nil
@@ -552,44 +1074,43 @@ func (s *Session) CloseInventoryCounting(cfg Config, id int) (bool, error) {
 
 	_, _, err = s.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("could not read response body content due to %s", err)
+		return false, fmt.Errorf("could not read response body content due to %w", err)
 	}
 
 	return true, nil
 }
 
+// AddLinesToInventoryCounting appends lines, which must not have a LineNum yet, to the
+// counting. Only the new lines are sent, so lines counted meanwhile by others are kept, and
+// the request is sent without If-Match since a stale ETag would refuse it for nothing.
 func (s *Session) AddLinesToInventoryCounting(cfg Config, id int, lines []InventoryCountingLine) (bool, error) {
-	// Retrieve the existing inventory counting
-	existingCounting, err := s.GetInventoryCounting(cfg, id)
-	if err != nil {
-		return false, err
-	}
-
-	// Append new lines to the existing lines
-	existingCounting.InventoryCountingLines = append(existingCounting.InventoryCountingLines, lines...)
+	patch := NewPatch().Set("InventoryCountingLines", lines)
+	patch.unconditional = true
 
-	// Prepare the payload for the update
-	payload, err := json.Marshal(existingCounting)
-	if err != nil {
+	if err := s.PatchInventoryCounting(cfg, id, patch); err != nil {
 		return false, err
 	}
 
-	// Send the PATCH request to update the inventory counting
-	url := cfg.GetInventoryCountingEndpoint(id)
-	req, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(string(payload)))
-	if err != nil {
-		return false, err
-	}
+	return true, nil
+}
 
-	_, _, err = s.Do(req)
-	if err != nil {
-		return false, fmt.Errorf("could not read response body content due to %s", err)
+// PatchInventoryCounting sends only the properties of patch, e.g. to set a counted quantity
+// to 0:
+//
+//	line := gosap.NewPatch().Set("LineNumber", 3).Set("CountedQuantity", gosap.Zero)
+//	patch := gosap.NewPatch().Set("InventoryCountingLines", []*gosap.Patch{line})
+func (s *Session) PatchInventoryCounting(cfg Config, id int, patch *Patch) error {
+	if err := updateDocument(s, cfg.GetInventoryCountingEndpoint(id), patch); err != nil {
+		return fmt.Errorf("could not patch inventory counting %d due to %w", id, err)
 	}
 
-	return true, nil
+	return nil
 }
 
-func (s *Session) GetAllInventoryCountingsWithLines(cfg Config) ([]InventoryCounting, error) {
+func (s *Session) GetAllInventoryCountingsWithLines(cfg Config) (_ []InventoryCounting, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
 	// Fetch the list of inventory countings
 	inventoryCountings, err := s.GetInventoryCountings(cfg)
 	if err != nil {
@@ -610,13 +1131,16 @@ func (s *Session) GetAllInventoryCountingsWithLines(cfg Config) ([]InventoryCoun
 }
 
 // Fetches all bin locations
-func (s *Session) GetBinLocations(cfg Config) ([]BinLocation, error) {
-	return s.getBinLocations(cfg, cfg.GetBinLocationsEndpoint())
+func (s *Session) GetBinLocations(cfg Config) (_ []BinLocation, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	return s.getBinLocations(cfg, cfg.GetBinLocationsEndpoint(), 1)
 }
 
 // getBinLocations fetches all bin locations from the SAP Service Layer
-func (s *Session) getBinLocations(cfg Config, endpoint string) ([]BinLocation, error) {
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+func (s *Session) getBinLocations(cfg Config, endpoint string, page int) ([]BinLocation, error) {
+	req, err := newPageRequest(endpoint, page)
 	if err != nil {
 		return nil, fmt.Errorf("could not create request: %w", err)
 	}
@@ -635,7 +1159,7 @@ func (s *Session) getBinLocations(cfg Config, endpoint string) ([]BinLocation, e
 	// Handle pagination via NextLink
 	if response.NextLink != nil && *response.NextLink != "" {
 		nextEndpoint := cfg.BuildEndpoint(*response.NextLink)
-		nextLocations, err := s.getBinLocations(cfg, nextEndpoint)
+		nextLocations, err := s.getBinLocations(cfg, nextEndpoint, page+1)
 		if err != nil {
 			return response.Value, err
 		}
@@ -674,7 +1198,7 @@ func (s *Session) UpdateBinLocation(cfg Config, id int, updatePayload string) er
 
 	_, _, err = s.Do(req)
 	if err != nil {
-		return fmt.Errorf("could not update bin location due to %s", err)
+		return fmt.Errorf("could not update bin location due to %w", err)
 	}
 
 	return nil
@@ -684,12 +1208,217 @@ func (s *Session) UpdateBinLocation(cfg Config, id int, updatePayload string) er
 func (s *Session) DeleteBinLocation(cfg Config, id int) error {
 	req, err := http.NewRequest(http.MethodDelete, cfg.DeleteBinLocationEndpoint(id), nil)
 	if err != nil {
-		return fmt.Errorf("could not create delete request due to %s", err)
+		return fmt.Errorf("could not create delete request due to %w", err)
+	}
+
+	_, _, err = s.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete bin location due to %w", err)
+	}
+
+	return nil
+}
+
+// Creates a bin location. SAP builds the BinCode from the warehouse and sublevels.
+func (s *Session) CreateBinLocation(cfg Config, location BinLocation) (*BinLocation, error) {
+	created, err := createDocument[BinLocation](s, cfg.CreateBinLocationEndpoint(), location)
+	if err != nil {
+		return nil, fmt.Errorf("could not create bin location due to %w", err)
+	}
+
+	return created, nil
+}
+
+// Creates every bin location described by layout. Creation stops at the first failure and
+// the bins created so far are returned alongside the error.
+func (s *Session) CreateBinLocations(cfg Config, layout BinLayout) (_ []BinLocation, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	locations, err := layout.BinLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]BinLocation, 0, len(locations))
+	for _, location := range locations {
+		bin, err := s.CreateBinLocation(cfg, location)
+		if err != nil {
+			return created, err
+		}
+
+		created = append(created, *bin)
+	}
+
+	return created, nil
+}
+
+// Updates only the fields set on updates for a specific bin location
+func (s *Session) PatchBinLocation(cfg Config, id int, updates BinLocationUpdate) error {
+	if err := updateDocument(s, cfg.UpdateBinLocationEndpoint(id), updates); err != nil {
+		return fmt.Errorf("could not update bin location due to %w", err)
+	}
+
+	return nil
+}
+
+// Fetches the warehouse and sublevel fields that make up bin codes
+func (s *Session) GetBinLocationFields(cfg Config) ([]BinLocationField, error) {
+	return retrieveDocuments[BinLocationField](s, cfg, cfg.GetBinLocationFieldsEndpoint())
+}
+
+// Fetches a specific bin location field by ID
+func (s *Session) GetBinLocationField(cfg Config, id int) (*BinLocationField, error) {
+	return retrieveDocument[BinLocationField](s, cfg.GetBinLocationFieldEndpoint(id))
+}
+
+// Updates a bin location field, e.g. to activate a sublevel or rename it
+func (s *Session) UpdateBinLocationField(cfg Config, id int, updates BinLocationFieldUpdate) error {
+	if err := updateDocument(s, cfg.GetBinLocationFieldEndpoint(id), updates); err != nil {
+		return fmt.Errorf("could not update bin location field due to %w", err)
+	}
+
+	return nil
+}
+
+// Fetches all bin location attribute codes
+func (s *Session) GetBinLocationAttributes(cfg Config) ([]BinLocationAttribute, error) {
+	return retrieveDocuments[BinLocationAttribute](s, cfg, cfg.GetBinLocationAttributesEndpoint())
+}
+
+// Creates a code for one of the ten bin location attributes
+func (s *Session) CreateBinLocationAttribute(cfg Config, attribute BinLocationAttribute) (*BinLocationAttribute, error) {
+	created, err := createDocument[BinLocationAttribute](s, cfg.GetBinLocationAttributesEndpoint(), attribute)
+	if err != nil {
+		return nil, fmt.Errorf("could not create bin location attribute due to %w", err)
+	}
+
+	return created, nil
+}
+
+// Deletes a bin location attribute code by ID
+func (s *Session) DeleteBinLocationAttribute(cfg Config, id int) error {
+	req, err := http.NewRequest(http.MethodDelete, cfg.GetBinLocationAttributeEndpoint(id), nil)
+	if err != nil {
+		return fmt.Errorf("could not create delete request due to %w", err)
+	}
+
+	_, _, err = s.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete bin location attribute due to %w", err)
+	}
+
+	return nil
+}
+
+// CreateDraft saves doc, any gosap document type, as a draft of the given object type
+// instead of posting it.
+func (s *Session) CreateDraft(cfg Config, objectCode DocumentObjectCode, doc any) (*Draft, error) {
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("could not use %T as a draft due to %s", doc, err)
+	}
+
+	fields["DocObjectCode"], err = json.Marshal(objectCode)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := createDocument[Draft](s, cfg.GetDraftsEndpoint(), fields)
+	if err != nil {
+		return nil, fmt.Errorf("could not create draft due to %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Session) GetDrafts(cfg Config) ([]Draft, error) {
+	return retrieveDocuments[Draft](s, cfg, cfg.GetDraftsEndpoint())
+}
+
+func (s *Session) GetDraft(cfg Config, id int) (*Draft, error) {
+	return retrieveDocument[Draft](s, cfg.GetDraftEndpoint(id))
+}
+
+// UpdateDraft sends the JSON encoding of updates, usually a document with only the changed
+// fields set, to the draft.
+func (s *Session) UpdateDraft(cfg Config, id int, updates any) error {
+	if err := updateDocument(s, cfg.GetDraftEndpoint(id), updates); err != nil {
+		return fmt.Errorf("could not update draft due to %w", err)
+	}
+
+	return nil
+}
+
+func (s *Session) DeleteDraft(cfg Config, id int) error {
+	req, err := http.NewRequest(http.MethodDelete, cfg.GetDraftEndpoint(id), nil)
+	if err != nil {
+		return fmt.Errorf("could not create delete request due to %w", err)
 	}
 
 	_, _, err = s.Do(req)
 	if err != nil {
-		return fmt.Errorf("could not delete bin location due to %s", err)
+		return fmt.Errorf("could not delete draft due to %w", err)
+	}
+
+	return nil
+}
+
+// SaveDraftToDocument posts the draft as a real document. Drafts under approval can only be
+// posted once their approval request is approved.
+func (s *Session) SaveDraftToDocument(cfg Config, id int) error {
+	payload, err := json.Marshal(map[string]map[string]int{"Document": {"DocEntry": id}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.SaveDraftToDocumentEndpoint(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not save draft %d to document due to %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *Session) GetApprovalRequests(cfg Config) ([]ApprovalRequest, error) {
+	return retrieveDocuments[ApprovalRequest](s, cfg, cfg.GetApprovalRequestsEndpoint())
+}
+
+func (s *Session) GetPendingApprovalRequests(cfg Config) ([]ApprovalRequest, error) {
+	return retrieveDocuments[ApprovalRequest](s, cfg, cfg.GetPendingApprovalRequestsEndpoint())
+}
+
+func (s *Session) GetApprovalRequest(cfg Config, code int) (*ApprovalRequest, error) {
+	return retrieveDocument[ApprovalRequest](s, cfg.GetApprovalRequestEndpoint(code))
+}
+
+// ApproveApprovalRequest records the approval of the session user on the request.
+func (s *Session) ApproveApprovalRequest(cfg Config, code int, remarks string) error {
+	return s.decideApprovalRequest(cfg, code, ApprovalDecisionApproved, remarks)
+}
+
+// RejectApprovalRequest records the rejection of the session user on the request.
+func (s *Session) RejectApprovalRequest(cfg Config, code int, remarks string) error {
+	return s.decideApprovalRequest(cfg, code, ApprovalDecisionNotApproved, remarks)
+}
+
+func (s *Session) decideApprovalRequest(cfg Config, code int, status BoApprovalRequestDecisionEnum, remarks string) error {
+	updates := ApprovalRequest{
+		ApprovalRequestDecisions: []ApprovalRequestDecision{{Status: status, Remarks: remarks}},
+	}
+
+	if err := updateDocument(s, cfg.GetApprovalRequestEndpoint(code), updates); err != nil {
+		return fmt.Errorf("could not decide approval request %d due to %w", code, err)
 	}
 
 	return nil
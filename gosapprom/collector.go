@@ -0,0 +1,127 @@
+// Package gosapprom exposes Prometheus metrics of the Service Layer calls made by gosap. It is
+// a separate module so gosap itself doesn't depend on the Prometheus client.
+//
+//	collector := gosapprom.NewCollector()
+//	prometheus.MustRegister(collector)
+//
+//	cfg.Middleware = append(cfg.Middleware, collector.Middleware())
+//	session, err := gosap.Authenticate(cfg)
+package gosapprom
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/octomiro/gosap"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "gosap"
+
+// StatusError is the status class of requests that got no response.
+const StatusError = "error"
+
+// Collector measures the requests sent through its middleware. It implements
+// prometheus.Collector, so it can be registered on any registry.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	pages    *prometheus.CounterVec
+	relogins *prometheus.CounterVec
+}
+
+// NewCollector returns a collector with no requests measured yet.
+func NewCollector() *Collector {
+	labels := []string{"operation", "status_class"}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to the Service Layer.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Time until the Service Layer answered.",
+			Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Requests sent again by RetryOnConflict after a conflict.",
+		}, labels),
+		pages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pages_fetched_total",
+			Help:      "Collection pages fetched while following odata.nextLink.",
+		}, labels),
+		relogins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "relogins_total",
+			Help:      "Sessions logged in again with Relogin.",
+		}, []string{"status_class"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.retries.Describe(ch)
+	c.pages.Describe(ch)
+	c.relogins.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.retries.Collect(ch)
+	c.pages.Collect(ch)
+	c.relogins.Collect(ch)
+}
+
+// Middleware returns the middleware measuring requests, to add to gosap.Config.Middleware.
+func (c *Collector) Middleware() gosap.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return gosap.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+
+			resp, err := next.RoundTrip(req)
+
+			c.observe(req, resp, time.Since(start))
+
+			return resp, err
+		})
+	}
+}
+
+func (c *Collector) observe(req *http.Request, resp *http.Response, elapsed time.Duration) {
+	op := gosap.OperationOf(req)
+
+	class := StatusError
+	if resp != nil {
+		class = StatusClass(resp.StatusCode)
+	}
+
+	c.requests.WithLabelValues(op, class).Inc()
+	c.duration.WithLabelValues(op, class).Observe(elapsed.Seconds())
+
+	if gosap.RetryAttempt(req.Context()) > 1 {
+		c.retries.WithLabelValues(op, class).Inc()
+	}
+
+	if gosap.PageOf(req) > 0 {
+		c.pages.WithLabelValues(op, class).Inc()
+	}
+
+	if op == "Relogin" {
+		c.relogins.WithLabelValues(class).Inc()
+	}
+}
+
+// StatusClass returns the class of an HTTP status, e.g. "4xx" for 404.
+func StatusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
@@ -0,0 +1,82 @@
+package gosapprom_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosapprom"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	server.PageSize = 2
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: fmt.Sprintf("A%05d", i)}))
+	}
+
+	collector := gosapprom.NewCollector()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	cfg := server.Config()
+	cfg.Middleware = []gosap.Middleware{collector.Middleware()}
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	_, err = session.GetItems(cfg)
+	require.NoError(t, err)
+
+	_, err = session.GetItem(cfg, "B00001")
+	require.Error(t, err)
+
+	attempts := 0
+	err = gosap.RetryOnConflictContext(context.Background(), 2, func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return gosap.ErrConflict
+		}
+
+		return session.WithContext(ctx).UpdateItem(cfg, "A00001", gosap.Item{ItemName: "Pallet"})
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.Relogin(cfg))
+
+	expected := `
+# HELP gosap_pages_fetched_total Collection pages fetched while following odata.nextLink.
+# TYPE gosap_pages_fetched_total counter
+gosap_pages_fetched_total{operation="GetItems",status_class="2xx"} 3
+# HELP gosap_relogins_total Sessions logged in again with Relogin.
+# TYPE gosap_relogins_total counter
+gosap_relogins_total{status_class="2xx"} 1
+# HELP gosap_requests_total Requests sent to the Service Layer.
+# TYPE gosap_requests_total counter
+gosap_requests_total{operation="Authenticate",status_class="2xx"} 1
+gosap_requests_total{operation="GetItem",status_class="4xx"} 1
+gosap_requests_total{operation="GetItems",status_class="2xx"} 3
+gosap_requests_total{operation="Relogin",status_class="2xx"} 1
+gosap_requests_total{operation="UpdateItem",status_class="2xx"} 1
+# HELP gosap_retries_total Requests sent again by RetryOnConflict after a conflict.
+# TYPE gosap_retries_total counter
+gosap_retries_total{operation="UpdateItem",status_class="2xx"} 1
+`
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"gosap_requests_total", "gosap_retries_total", "gosap_pages_fetched_total", "gosap_relogins_total"))
+
+	assert.Equal(t, 5, testutil.CollectAndCount(collector, "gosap_request_duration_seconds"))
+}
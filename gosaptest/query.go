@@ -0,0 +1,540 @@
+package gosaptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/octomiro/gosap"
+)
+
+// filter is a parsed $filter expression evaluated against an entity.
+type filter func(entity map[string]any) (any, error)
+
+// parseFilter parses the subset of OData $filter the Service Layer is queried with: eq, ne,
+// gt, ge, lt, le, and, or, not, parentheses and the startswith, endswith, contains and
+// substringof functions.
+func parseFilter(expr string) (filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q in $filter", p.peek().text)
+	}
+
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenClose, ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+		case r == '\'':
+			var b strings.Builder
+
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string in $filter")
+				}
+
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						b.WriteRune('\'')
+						i += 2
+
+						continue
+					}
+
+					i++
+
+					break
+				}
+
+				b.WriteRune(runes[i])
+				i++
+			}
+
+			tokens = append(tokens, token{tokenString, b.String()})
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			i++
+
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '/') {
+				i++
+			}
+
+			tokens = append(tokens, token{tokenIdent, string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected %q in $filter", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	if p.done() {
+		return token{kind: -1}
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenIdent && strings.EqualFold(t.text, word) {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *filterParser) expect(kind tokenKind, what string) error {
+	if p.peek().kind != kind {
+		return fmt.Errorf("expected %s in $filter", what)
+	}
+
+	p.pos++
+
+	return nil
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(e map[string]any) (any, error) {
+			a, err := truthy(l(e))
+			if err != nil || a {
+				return a, err
+			}
+
+			return truthy(right(e))
+		}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(e map[string]any) (any, error) {
+			a, err := truthy(l(e))
+			if err != nil || !a {
+				return a, err
+			}
+
+			return truthy(right(e))
+		}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filter, error) {
+	if p.keyword("not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return func(e map[string]any) (any, error) {
+			v, err := truthy(operand(e))
+
+			return !v, err
+		}, nil
+	}
+
+	return p.parseComparison()
+}
+
+var comparisons = map[string]func(int) bool{
+	"eq": func(c int) bool { return c == 0 },
+	"ne": func(c int) bool { return c != 0 },
+	"gt": func(c int) bool { return c > 0 },
+	"ge": func(c int) bool { return c >= 0 },
+	"lt": func(c int) bool { return c < 0 },
+	"le": func(c int) bool { return c <= 0 },
+}
+
+func (p *filterParser) parseComparison() (filter, error) {
+	aliases := p.propertyAliases()
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return left, nil
+	}
+
+	op := strings.ToLower(t.text)
+
+	matches, ok := comparisons[op]
+	if !ok {
+		return left, nil
+	}
+
+	p.pos++
+
+	if aliases == nil {
+		aliases = p.propertyAliases()
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(e map[string]any) (any, error) {
+		a, err := left(e)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := right(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == "eq" || op == "ne" {
+			return matches(boolToCmp(equal(a, b, aliases))), nil
+		}
+
+		c, ok := compare(a, b)
+
+		return ok && matches(c), nil
+	}, nil
+}
+
+// propertyAliases returns the enum aliases of the property about to be parsed, if any.
+func (p *filterParser) propertyAliases() map[string]string {
+	if t := p.peek(); t.kind == tokenIdent {
+		return enumAliases[t.text]
+	}
+
+	return nil
+}
+
+var functions = map[string]func(a, b string) bool{
+	"startswith":  strings.HasPrefix,
+	"endswith":    strings.HasSuffix,
+	"contains":    strings.Contains,
+	"substringof": func(a, b string) bool { return strings.Contains(b, a) },
+}
+
+func (p *filterParser) parseOperand() (filter, error) {
+	t := p.peek()
+
+	switch t.kind {
+	case tokenOpen:
+		p.pos++
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return inner, p.expect(tokenClose, ")")
+	case tokenString:
+		p.pos++
+
+		return constant(t.text), nil
+	case tokenNumber:
+		p.pos++
+
+		return constant(json.Number(t.text)), nil
+	case tokenIdent:
+		p.pos++
+
+		switch strings.ToLower(t.text) {
+		case "true":
+			return constant(true), nil
+		case "false":
+			return constant(false), nil
+		case "null":
+			return constant(nil), nil
+		}
+
+		if fn, ok := functions[strings.ToLower(t.text)]; ok && p.peek().kind == tokenOpen {
+			return p.parseFunction(t.text, fn)
+		}
+
+		name := t.text
+
+		return func(e map[string]any) (any, error) { return e[name], nil }, nil
+	}
+
+	return nil, fmt.Errorf("unexpected end of $filter")
+}
+
+func (p *filterParser) parseFunction(name string, fn func(a, b string) bool) (filter, error) {
+	p.pos++
+
+	first, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenComma, ","); err != nil {
+		return nil, err
+	}
+
+	second, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenClose, ")"); err != nil {
+		return nil, fmt.Errorf("%s takes two arguments", name)
+	}
+
+	return func(e map[string]any) (any, error) {
+		a, err := first(e)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := second(e)
+		if err != nil {
+			return nil, err
+		}
+
+		as, aok := a.(string)
+		bs, bok := b.(string)
+
+		return aok && bok && fn(as, bs), nil
+	}, nil
+}
+
+func constant(v any) filter {
+	return func(map[string]any) (any, error) { return v, nil }
+}
+
+func truthy(v any, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("$filter operand %v is not a condition", v)
+	}
+
+	return b, nil
+}
+
+func boolToCmp(b bool) int {
+	if b {
+		return 0
+	}
+
+	return 1
+}
+
+// enumAliases are the database values the Service Layer accepts in place of enum names, per
+// property.
+var enumAliases = map[string]map[string]string{
+	"CardType": {
+		"C": string(gosap.CardTypeCustomer),
+		"S": string(gosap.CardTypeSupplier),
+		"L": string(gosap.CardTypeLead),
+	},
+	"DocumentStatus": {
+		"O": string(gosap.BoStatusOpen),
+		"C": string(gosap.BoStatusClose),
+	},
+}
+
+func equal(a, b any, aliases map[string]string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if c, ok := compare(a, b); ok && c == 0 {
+		return true
+	}
+
+	as, aok := a.(string)
+	bs, bok := b.(string)
+
+	return aok && bok && (aliases[as] == bs || aliases[bs] == as)
+}
+
+// compare orders numbers, dates and strings. ok is false for values of different kinds.
+func compare(a, b any) (int, bool) {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+
+	if aok && bok {
+		ad, err := gosap.ParseDecimal(an.String())
+		if err != nil {
+			return 0, false
+		}
+
+		bd, err := gosap.ParseDecimal(bn.String())
+		if err != nil {
+			return 0, false
+		}
+
+		return ad.Cmp(bd), true
+	}
+
+	as, aok := a.(string)
+	bs, bok := b.(string)
+
+	if !aok || !bok {
+		if ab, ok := a.(bool); ok {
+			if bb, ok := b.(bool); ok {
+				return boolToCmp(ab == bb), true
+			}
+		}
+
+		return 0, false
+	}
+
+	if ad, err := gosap.ParseDate(as); err == nil && !ad.IsZero() {
+		if bd, err := gosap.ParseDate(bs); err == nil && !bd.IsZero() {
+			return ad.Compare(bd.Time), true
+		}
+	}
+
+	return strings.Compare(as, bs), true
+}
+
+// orderBy sorts entities by a $orderby list such as "DocDate desc,DocEntry".
+func orderBy(entities []map[string]any, spec string) {
+	type key struct {
+		name string
+		desc bool
+	}
+
+	var keys []key
+
+	for _, part := range strings.Split(spec, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+
+		keys = append(keys, key{name: fields[0], desc: len(fields) > 1 && strings.EqualFold(fields[1], "desc")})
+	}
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		for _, k := range keys {
+			c, _ := compare(entities[i][k.name], entities[j][k.name])
+			if c == 0 {
+				continue
+			}
+
+			if k.desc {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+}
+
+// project keeps the $select properties of entity.
+func project(entity map[string]any, selection string) map[string]any {
+	if selection == "" || selection == "*" {
+		return entity
+	}
+
+	projected := map[string]any{}
+
+	for _, name := range strings.Split(selection, ",") {
+		name = strings.TrimSpace(name)
+		if v, ok := entity[name]; ok {
+			projected[name] = v
+		}
+	}
+
+	return projected
+}
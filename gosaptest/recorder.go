@@ -0,0 +1,349 @@
+package gosaptest
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/octomiro/gosap"
+)
+
+// Mode selects whether a Recorder talks to the Service Layer or serves a cassette.
+type Mode int
+
+const (
+	// Replay serves the exchanges of the cassette without any network access.
+	Replay Mode = iota
+	// Record sends requests to the Service Layer and saves the exchanges to the cassette.
+	Record
+)
+
+// Redacted replaces credentials, session ids and cookies in cassettes.
+const Redacted = "REDACTED"
+
+// Cassette holds the recorded exchanges with a Service Layer.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as sent, with the host left out so cassettes replay against any
+// configured server.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording Service Layer exchanges to a cassette file or
+// replaying them from it. The login password, the session id and the session cookies are
+// redacted before anything is kept.
+//
+//	rec, err := gosaptest.NewRecorder("testdata/cassettes/orders.json", gosaptest.Replay)
+//	cfg.Transport = rec
+//	session, err := gosap.Authenticate(cfg)
+//	...
+//	err = rec.Save()
+type Recorder struct {
+	// Transport sends the requests while recording. Nil uses one that doesn't verify the
+	// Service Layer certificate, like gosap does.
+	Transport http.RoundTripper
+
+	mode     Mode
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// NewRecorder returns a recorder for the cassette at path. Replaying requires the cassette to
+// exist; recording starts a new one.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+
+	if mode == Record {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read cassette due to %s", err)
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("could not load cassette %s due to %s", path, err)
+	}
+
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Config returns cfg sending its requests through the recorder.
+func (r *Recorder) Config(cfg gosap.Config) gosap.Config {
+	cfg.Transport = r
+
+	return cfg
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Interactions returns the exchanges recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes the recorded exchanges to the cassette file. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("could not encode cassette due to %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("could not create cassette directory due to %s", err)
+	}
+
+	if err := os.WriteFile(r.path, data, 0o600); err != nil {
+		return fmt.Errorf("could not write cassette due to %s", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == Replay {
+		return r.replay(req, recorded)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body due to %s", err)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: redactHeader(resp.Header),
+			Body:   redactBody(body),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching the method, URL and body of the
+// request, falling back to the last used one so repeated requests keep working.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+
+	for i, interaction := range r.cassette.Interactions {
+		if !interaction.Request.matches(recorded) {
+			continue
+		}
+
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, recorded.Method, recorded.URL)
+	}
+
+	r.replayed[match] = true
+	recordedResp := r.cassette.Interactions[match].Response
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.Status, http.StatusText(recordedResp.Status)),
+		StatusCode:    recordedResp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recordedResp.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recordedResp.Body)),
+		ContentLength: int64(len(recordedResp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// ErrNotRecorded is returned when replaying a request missing from the cassette.
+var ErrNotRecorded = errors.New("request not recorded in cassette")
+
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method && rr.URL == other.URL && rr.Body == other.Body
+}
+
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Header: redactHeader(req.Header),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return recorded, fmt.Errorf("could not read request body due to %s", err)
+	}
+
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = redactBody(body)
+
+	return recorded, nil
+}
+
+// sensitiveHeaders carry credentials or session cookies.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+
+	for _, name := range sensitiveHeaders {
+		values := redacted.Values(name)
+		for i, value := range values {
+			values[i] = redactCookies(name, value)
+		}
+	}
+
+	return redacted
+}
+
+// redactCookies replaces the cookie values of a Cookie or Set-Cookie header, keeping their names
+// and attributes.
+func redactCookies(header, value string) string {
+	if header == "Authorization" {
+		return Redacted
+	}
+
+	parts := strings.Split(value, ";")
+
+	for i, part := range parts {
+		name, _, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		// Only the first pair of Set-Cookie is the cookie, the rest are attributes.
+		if header == "Cookie" || i == 0 {
+			parts[i] = name + "=" + Redacted
+			if i > 0 {
+				parts[i] = " " + parts[i]
+			}
+		}
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// sensitiveProperties are the JSON properties of the Login exchange carrying secrets or naming
+// the company and user, so cassettes replay whatever credentials are configured. Properties
+// ending in Password, such as ApproverPassword, are redacted as well.
+var sensitiveProperties = []string{"CompanyDB", "UserName", "Password", "SessionId"}
+
+func isSensitive(name string) bool {
+	return strings.HasSuffix(name, "Password") || slices.Contains(sensitiveProperties, name)
+}
+
+// redactBody redacts the secret properties of a JSON object at any depth, leaving bodies without
+// them untouched.
+func redactBody(body []byte) string {
+	props, err := decodeEntity(body)
+	if err != nil || !redactValue(props) {
+		return string(body)
+	}
+
+	data, err := json.Marshal(props)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(data)
+}
+
+// redactValue redacts the secret properties of the objects in value and reports whether it
+// found any.
+func redactValue(value any) bool {
+	redacted := false
+
+	switch value := value.(type) {
+	case map[string]any:
+		for name, v := range value {
+			if isSensitive(name) {
+				value[name] = Redacted
+				redacted = true
+			} else if redactValue(v) {
+				redacted = true
+			}
+		}
+	case []any:
+		for _, v := range value {
+			if redactValue(v) {
+				redacted = true
+			}
+		}
+	}
+
+	return redacted
+}
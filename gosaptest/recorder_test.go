@@ -0,0 +1,104 @@
+package gosaptest_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "items.json")
+
+	server := gosaptest.NewServer()
+	require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: "A00001", ItemName: "Pallet"}))
+
+	rec, err := gosaptest.NewRecorder(path, gosaptest.Record)
+	require.NoError(t, err)
+
+	cfg := rec.Config(server.Config())
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	recorded, err := session.GetItem(cfg, "A00001")
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+
+	server.Close()
+
+	cassette, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(cassette), gosaptest.Password)
+	assert.NotContains(t, string(cassette), gosaptest.CompanyDB)
+	assert.NotContains(t, string(cassette), session.B1Session)
+	assert.Contains(t, string(cassette), "B1SESSION="+gosaptest.Redacted)
+
+	rec, err = gosaptest.NewRecorder(path, gosaptest.Replay)
+	require.NoError(t, err)
+
+	cfg = rec.Config(cfg)
+
+	session, err = gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	replayed, err := session.GetItem(cfg, "A00001")
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	_, err = session.GetItem(cfg, "A00002")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, gosaptest.ErrNotRecorded))
+
+	cfg.Password = "wrong"
+	_, err = gosap.Authenticate(cfg)
+	require.NoError(t, err, "the password is redacted before matching")
+
+	cfg.CompanyDB, cfg.Username = "SBOPROD", "ops"
+	_, err = gosap.Authenticate(cfg)
+	require.NoError(t, err, "the company and user are redacted before matching")
+}
+
+func TestRecorderRedactsNestedPasswords(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "approval.json")
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("ApprovalRequests", gosap.ApprovalRequest{Code: 3}))
+
+	decide := func(cfg gosap.Config, password string) error {
+		session, err := gosap.Authenticate(cfg)
+		require.NoError(t, err)
+
+		patch := gosap.NewPatch().Set("ApprovalRequestDecisions", []gosap.ApprovalRequestDecision{
+			{ApproverUserName: "manager", ApproverPassword: password, Status: gosap.ApprovalDecisionApproved},
+		})
+
+		return session.PatchEntity(cfg.GetApprovalRequestEndpoint(3), patch)
+	}
+
+	rec, err := gosaptest.NewRecorder(path, gosaptest.Record)
+	require.NoError(t, err)
+	require.NoError(t, decide(rec.Config(server.Config()), "approver-secret"))
+	require.NoError(t, rec.Save())
+
+	cassette, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(cassette), "approver-secret")
+	assert.Contains(t, string(cassette), "manager")
+
+	rec, err = gosaptest.NewRecorder(path, gosaptest.Replay)
+	require.NoError(t, err)
+	require.NoError(t, decide(rec.Config(server.Config()), "other-secret"),
+		"the approver password is redacted before matching")
+}
@@ -0,0 +1,665 @@
+// Package gosaptest provides an in-memory fake of the SAP Business One Service Layer, so code
+// using gosap can be tested without a SAP instance.
+//
+//	server := gosaptest.NewServer()
+//	defer server.Close()
+//
+//	server.Seed("Items", gosap.Item{ItemCode: "A00001", ItemName: "Pallet"})
+//
+//	cfg := server.Config()
+//	session, err := gosap.Authenticate(cfg)
+//	item, err := session.GetItem(cfg, "A00001")
+package gosaptest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/octomiro/gosap"
+)
+
+const (
+	// DefaultPageSize is the number of entities per page the Service Layer returns by default.
+	DefaultPageSize = 20
+
+	CompanyDB = "SBODEMO"
+	Username  = "manager"
+	Password  = "B1Admin!"
+
+	servicePrefix = "/b1s/"
+)
+
+// dialect is the way a Service Layer API version annotates its responses.
+type dialect struct {
+	prefix      string
+	contextKey  string
+	nextLinkKey string
+	// nextLinkBase is prepended to the entity set in nextLinks.
+	nextLinkBase string
+}
+
+// dialects are the Service Layer API versions served: v1 speaks OData v3, v2 OData v4 with
+// nextLinks relative to the service root.
+var dialects = map[string]dialect{
+	"v1": {
+		prefix:       servicePrefix + "v1/",
+		contextKey:   "odata.metadata",
+		nextLinkKey:  "odata.nextLink",
+		nextLinkBase: servicePrefix + "v1/",
+	},
+	"v2": {
+		prefix:      servicePrefix + "v2/",
+		contextKey:  "@odata.context",
+		nextLinkKey: "@odata.nextLink",
+	},
+}
+
+// dialectOf returns the dialect of the API version r is sent to.
+func dialectOf(r *http.Request) (dialect, bool) {
+	version, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, servicePrefix), "/")
+	d, ok := dialects[version]
+
+	return d, ok && strings.HasPrefix(r.URL.Path, servicePrefix)
+}
+
+// Server is a fake Service Layer serving entity sets kept in memory over TLS, through the v1
+// and v2 APIs. It supports Login and Logout with session cookies routed by ROUTEID, reading
+// with $filter, $select, $orderby, $top and $skip and nextLink paging, creating, patching (with
+// ETag checks) and deleting entities, the Close, Cancel and Reopen document actions and a few
+// services.
+type Server struct {
+	*httptest.Server
+
+	// PageSize is the number of entities per page unless the client asks for another one with
+	// Prefer: odata.maxpagesize.
+	PageSize int
+	// Precision is returned by CompanyService_GetAdminInfo.
+	Precision gosap.DecimalPrecision
+	// Nodes is the number of Service Layer nodes behind the load balancer. Logins are spread
+	// over them, and a session only works with the ROUTEID of the node it logged in on.
+	Nodes int
+
+	mu     sync.Mutex
+	sets   map[string]*entitySet
+	logins int
+	// sessions holds the ROUTEID of each session logged in.
+	sessions map[string]string
+}
+
+// NewServer starts a fake Service Layer with the entity sets gosap supports, all empty.
+func NewServer() *Server {
+	s := &Server{
+		PageSize:  DefaultPageSize,
+		Precision: gosap.DefaultDecimalPrecision,
+		Nodes:     1,
+		sets:      defaultEntitySets(),
+		sessions:  map[string]string{},
+	}
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Config returns the configuration to reach the server and log in to it.
+func (s *Server) Config() gosap.Config {
+	host, port, _ := net.SplitHostPort(s.Listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+
+	return gosap.Config{
+		IP:        host,
+		Port:      uint16(portNum),
+		CompanyDB: CompanyDB,
+		Username:  Username,
+		Password:  Password,
+	}
+}
+
+// Sessions returns the number of sessions logged in.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// ExpireSessions ends all sessions as if they timed out.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]string{}
+}
+
+// AddEntitySet registers an entity set, e.g. the one of a user-defined object, identified by
+// keys. A single DocEntry, DocumentEntry or AbsEntry key is numbered by the server. Entity sets
+// of user tables (U_...) are registered on first use with the key Code.
+func (s *Server) AddEntitySet(name string, keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sets[name] = newEntitySet(name, keys...)
+}
+
+// Seed adds entities to an entity set as if they were created through the Service Layer.
+// Entities can be gosap types, maps or raw JSON.
+func (s *Server) Seed(entitySet string, entities ...any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, err := s.entitySet(entitySet)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range entities {
+		props, err := toEntity(v)
+		if err != nil {
+			return fmt.Errorf("could not seed %s due to %s", entitySet, err)
+		}
+
+		if _, err := set.create(props); err != nil {
+			return fmt.Errorf("could not seed %s due to %s", entitySet, err)
+		}
+	}
+
+	return nil
+}
+
+// Entities returns a copy of the entities of an entity set in creation order.
+func (s *Server) Entities(entitySet string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, ok := s.sets[entitySet]
+	if !ok {
+		return nil
+	}
+
+	res := make([]map[string]any, 0, len(set.entities))
+	for _, e := range set.entities {
+		res = append(res, clone(e.props))
+	}
+
+	return res
+}
+
+// Load decodes the entity of entitySet with the key predicate key, e.g. "'A00001'" or "12",
+// into dest.
+func (s *Server) Load(entitySet, key string, dest any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	set, ok := s.sets[entitySet]
+	if !ok {
+		return fmt.Errorf("unknown entity set %s", entitySet)
+	}
+
+	keys, err := set.parseKeys(key)
+	if err != nil {
+		return err
+	}
+
+	_, e := set.find(keys)
+	if e == nil {
+		return fmt.Errorf("%s(%s) does not exist", entitySet, key)
+	}
+
+	data, err := json.Marshal(e.props)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+// entitySet returns the named entity set, registering user tables on first use.
+func (s *Server) entitySet(name string) (*entitySet, error) {
+	if set, ok := s.sets[name]; ok {
+		return set, nil
+	}
+
+	if strings.HasPrefix(name, "U_") {
+		s.sets[name] = newEntitySet(name, "Code")
+
+		return s.sets[name], nil
+	}
+
+	return nil, fmt.Errorf("unknown entity set %s", name)
+}
+
+// ServiceLayerError is the body of failed Service Layer responses.
+type ServiceLayerError struct {
+	Error struct {
+		Code    int `json:"code"`
+		Message struct {
+			Lang  string `json:"lang"`
+			Value string `json:"value"`
+		} `json:"message"`
+	} `json:"error"`
+}
+
+// Error codes the fake returns, taken from the Service Layer.
+const (
+	codeInvalidSession = 301
+	codeNotFound       = -2028
+	codeInvalid        = -5002
+	codeConflict       = -1
+)
+
+func writeError(w http.ResponseWriter, status, code int, format string, args ...any) {
+	var body ServiceLayerError
+
+	body.Error.Code = code
+	body.Error.Message.Lang = "en-us"
+	body.Error.Message.Value = fmt.Sprintf(format, args...)
+
+	writeJSON(w, status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json;odata=minimalmetadata;charset=utf-8")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// entityPath matches EntitySet, EntitySet(key) and EntitySet(key)/Action.
+var entityPath = regexp.MustCompile(`^(\w+)(?:\((.*)\))?(?:/(\w+))?$`)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	d, ok := dialectOf(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, codeNotFound, "Invalid resource %s", r.URL.Path)
+
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, d.prefix)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "could not read request: %s", err)
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if path == "Login" && r.Method == http.MethodPost {
+		s.login(w, d, body)
+
+		return
+	}
+
+	if !s.authenticated(r) {
+		writeError(w, http.StatusUnauthorized, codeInvalidSession, "Invalid session or session already timeout.")
+
+		return
+	}
+
+	switch path {
+	case "Logout":
+		if cookie, err := r.Cookie("B1SESSION"); err == nil {
+			delete(s.sessions, cookie.Value)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	case "CompanyService_GetAdminInfo":
+		writeJSON(w, http.StatusOK, map[string]any{
+			"TotalsAccuracy":     s.Precision.Amounts,
+			"PriceAccuracy":      s.Precision.Prices,
+			"RateAccuracy":       s.Precision.Rates,
+			"QuantityAccuracy":   s.Precision.Quantities,
+			"PercentageAccuracy": s.Precision.Percents,
+			"MeasuringAccuracy":  s.Precision.Measures,
+		})
+
+		return
+	case "DraftsService_SaveDraftToDocument":
+		s.saveDraftToDocument(w, body)
+
+		return
+	}
+
+	m := entityPath.FindStringSubmatch(path)
+	if m == nil {
+		writeError(w, http.StatusNotFound, codeNotFound, "Invalid resource %s", path)
+
+		return
+	}
+
+	set, err := s.entitySet(m[1])
+	if err != nil {
+		writeError(w, http.StatusNotFound, codeNotFound, "Invalid resource %s", m[1])
+
+		return
+	}
+
+	if m[2] == "" && m[3] == "" {
+		s.serveCollection(w, r, set, body)
+
+		return
+	}
+
+	keys, err := set.parseKeys(m[2])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+		return
+	}
+
+	index, e := set.find(keys)
+	if e == nil {
+		writeError(w, http.StatusNotFound, codeNotFound, "No matching records found (ODBC -2028)")
+
+		return
+	}
+
+	if m[3] != "" {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, codeInvalid, "%s requires POST", m[3])
+
+			return
+		}
+
+		if err := set.action(e, m[3]); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	s.serveEntity(w, r, set, index, e, body)
+}
+
+func (s *Server) login(w http.ResponseWriter, d dialect, body []byte) {
+	var credentials struct {
+		CompanyDB string
+		UserName  string
+		Password  string
+	}
+
+	if err := json.Unmarshal(body, &credentials); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "invalid login: %s", err)
+
+		return
+	}
+
+	if credentials.CompanyDB != CompanyDB || credentials.UserName != Username || credentials.Password != Password {
+		writeError(w, http.StatusUnauthorized, -304, "Fail to get DB Credentials from SLD")
+
+		return
+	}
+
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	session := hex.EncodeToString(token)
+	routeID := fmt.Sprintf(".node%d", s.logins%max(s.Nodes, 1))
+	s.logins++
+	s.sessions[session] = routeID
+
+	http.SetCookie(w, &http.Cookie{Name: "B1SESSION", Value: session, Path: strings.TrimSuffix(d.prefix, "/"), HttpOnly: true})
+	http.SetCookie(w, &http.Cookie{Name: "ROUTEID", Value: routeID, Path: "/b1s"})
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		d.contextKey:     "https://" + s.Listener.Addr().String() + d.prefix + "$metadata#B1Sessions/@Element",
+		"SessionId":      session,
+		"Version":        "1000191",
+		"SessionTimeout": 30,
+	})
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	cookie, err := r.Cookie("B1SESSION")
+	if err != nil {
+		return false
+	}
+
+	routeID, ok := s.sessions[cookie.Value]
+	if !ok {
+		return false
+	}
+
+	route, err := r.Cookie("ROUTEID")
+
+	return err == nil && route.Value == routeID
+}
+
+func (s *Server) metadata(d dialect, set *entitySet, suffix string) string {
+	return "https://" + s.Listener.Addr().String() + d.prefix + "$metadata#" + set.name + suffix
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, set *entitySet, body []byte) {
+	d, _ := dialectOf(r)
+
+	switch r.Method {
+	case http.MethodGet:
+		s.list(w, r, set)
+	case http.MethodPost:
+		props, err := decodeEntity(body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+			return
+		}
+
+		e, err := set.create(props)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+			return
+		}
+
+		w.Header().Set("ETag", e.etag())
+		w.Header().Set("Location", s.URL+d.prefix+set.name+"("+set.keyPredicate(e.props)+")")
+		writeJSON(w, http.StatusCreated, withMetadata(e.props, d, s.metadata(d, set, "/@Element")))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, codeInvalid, "%s is not allowed on %s", r.Method, set.name)
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, set *entitySet) {
+	d, _ := dialectOf(r)
+	options := r.URL.Query()
+
+	entities, err := set.query(options)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+		return
+	}
+
+	skip, _ := strconv.Atoi(options.Get("$skip"))
+	skip = min(max(skip, 0), len(entities))
+	remaining := len(entities) - skip
+
+	if top, err := strconv.Atoi(options.Get("$top")); err == nil && top >= 0 {
+		remaining = min(remaining, top)
+	}
+
+	pageSize := s.pageSize(r)
+	page := entities[skip : skip+min(remaining, pageSize)]
+
+	values := make([]map[string]any, 0, len(page))
+	for _, props := range page {
+		values = append(values, project(props, options.Get("$select")))
+	}
+
+	res := map[string]any{d.contextKey: s.metadata(d, set, ""), "value": values}
+
+	if remaining > len(page) {
+		next := url.Values{}
+
+		for name, value := range options {
+			if name != "$skip" && name != "$top" {
+				next[name] = value
+			}
+		}
+
+		next.Set("$skip", strconv.Itoa(skip+len(page)))
+
+		if options.Get("$top") != "" {
+			next.Set("$top", strconv.Itoa(remaining-len(page)))
+		}
+
+		res[d.nextLinkKey] = d.nextLinkBase + set.name + "?" + next.Encode()
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) pageSize(r *http.Request) int {
+	for _, pref := range strings.Split(r.Header.Get("Prefer"), ",") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(pref), "odata.maxpagesize="); ok {
+			if n, err := strconv.Atoi(value); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+
+	if s.PageSize > 0 {
+		return s.PageSize
+	}
+
+	return DefaultPageSize
+}
+
+func (s *Server) serveEntity(w http.ResponseWriter, r *http.Request, set *entitySet, index int, e *entity, body []byte) {
+	if r.Method == http.MethodPatch || r.Method == http.MethodDelete {
+		if match := r.Header.Get("If-Match"); match != "" && match != "*" && match != e.etag() {
+			writeError(w, http.StatusPreconditionFailed, codeConflict,
+				"Another user or another operation modified data; to continue, open the object again")
+
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("ETag", e.etag())
+
+		d, _ := dialectOf(r)
+		props := project(e.props, r.URL.Query().Get("$select"))
+		writeJSON(w, http.StatusOK, withMetadata(props, d, s.metadata(d, set, "/@Element")))
+	case http.MethodPatch:
+		props, err := decodeEntity(body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+			return
+		}
+
+		replace := strings.EqualFold(r.Header.Get("B1S-ReplaceCollectionsOnPatch"), "true")
+		if err := set.patch(e, props, replace); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		set.entities = append(set.entities[:index], set.entities[index+1:]...)
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, codeInvalid, "%s is not allowed on %s", r.Method, set.name)
+	}
+}
+
+// saveDraftToDocument creates the document of a draft in the entity set of its DocObjectCode
+// and closes the draft.
+func (s *Server) saveDraftToDocument(w http.ResponseWriter, body []byte) {
+	var req struct {
+		Document struct {
+			DocEntry json.Number
+		}
+	}
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+		return
+	}
+
+	drafts := s.sets["Drafts"]
+
+	_, draft := drafts.find(map[string]string{"DocEntry": req.Document.DocEntry.String()})
+	if draft == nil {
+		writeError(w, http.StatusNotFound, codeNotFound, "No matching records found (ODBC -2028)")
+
+		return
+	}
+
+	for _, e := range s.sets["ApprovalRequests"].entities {
+		if keyString(e.props["DraftEntry"]) != keyString(draft.props["DocEntry"]) {
+			continue
+		}
+
+		if status := e.props["Status"]; status != string(gosap.ApprovalRequestApproved) {
+			writeError(w, http.StatusBadRequest, codeInvalid, "Draft %v can not be added, approval request is %v",
+				draft.props["DocEntry"], status)
+
+			return
+		}
+	}
+
+	var target *entitySet
+
+	for _, kind := range documentKinds {
+		if draft.props["DocObjectCode"] == string(kind.ObjectCode) {
+			target = s.sets[kind.EntitySet]
+		}
+	}
+
+	if target == nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "unsupported DocObjectCode %v", draft.props["DocObjectCode"])
+
+		return
+	}
+
+	props := clone(draft.props)
+	for _, name := range []string{"DocEntry", "DocNum", "DocObjectCode", "DocumentStatus"} {
+		delete(props, name)
+	}
+
+	if _, err := target.create(props); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalid, "%s", err)
+
+		return
+	}
+
+	draft.props["DocumentStatus"] = string(gosap.BoStatusClose)
+	draft.version++
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func withMetadata(props map[string]any, d dialect, metadata string) map[string]any {
+	res := make(map[string]any, len(props)+1)
+	for name, value := range props {
+		res[name] = value
+	}
+
+	res[d.contextKey] = metadata
+
+	return res
+}
@@ -0,0 +1,319 @@
+package gosaptest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSession(t *testing.T, server *gosaptest.Server) (*gosap.Session, gosap.Config) {
+	t.Helper()
+
+	cfg := server.Config()
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, session.B1Session)
+
+	return session, cfg
+}
+
+func TestServerLogin(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+	cfg.Password = "wrong"
+
+	_, err := gosap.Authenticate(cfg)
+	require.Error(t, err)
+
+	session, cfg := newSession(t, server)
+
+	_, err = session.GetItems(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.Logout(cfg))
+
+	_, err = session.GetItems(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+
+	derived := session.WithContext(context.Background())
+	require.NoError(t, derived.Relogin(cfg))
+
+	_, err = session.GetItems(cfg)
+	require.NoError(t, err)
+
+	_, err = derived.GetItems(cfg)
+	require.NoError(t, err)
+}
+
+func TestServerPaging(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	server.PageSize = 3
+
+	for i := 1; i <= 8; i++ {
+		require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: fmt.Sprintf("A%05d", i), ItemName: "Pallet"}))
+	}
+
+	session, cfg := newSession(t, server)
+
+	items, err := session.GetItems(cfg, "ItemCode")
+	require.NoError(t, err)
+	require.Len(t, items.Value, 8)
+	assert.Equal(t, "A00008", items.Value[7].ItemCode)
+	assert.Empty(t, items.Value[7].ItemName)
+}
+
+func TestServerFilter(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("BusinessPartners",
+		gosap.BusinessPartner{CardCode: "C001", CardName: "Acme", CardType: gosap.CardTypeCustomer},
+		gosap.BusinessPartner{CardCode: "S001", CardName: "Globex", CardType: gosap.CardTypeSupplier},
+		gosap.BusinessPartner{CardCode: "C002", CardName: "Initech", CardType: gosap.CardTypeCustomer},
+	))
+
+	session, cfg := newSession(t, server)
+
+	clients, err := session.GetClients(cfg)
+	require.NoError(t, err)
+	require.Len(t, clients.Value, 2)
+	assert.Equal(t, "C002", clients.Value[1].CardCode)
+
+	require.NoError(t, server.Seed("SpecialPrices",
+		gosap.SpecialPrice{ItemCode: "A00001", CardCode: "C001", Price: "10"},
+		gosap.SpecialPrice{ItemCode: "A00001", CardCode: "C002", Price: "12"},
+		gosap.SpecialPrice{ItemCode: "A00002", CardCode: "C001", Price: "9"},
+	))
+
+	prices := get[gosap.SpecialPrice](t, session, cfg.GetItemSpecialPricesEndpoint("A00001", "C002", "C003"))
+	require.Len(t, prices, 1)
+	assert.Equal(t, gosap.Decimal("12"), prices[0].Price)
+
+	partners := get[gosap.BusinessPartner](t, session, cfg.BuildEndpoint(
+		"/b1s/v1/BusinessPartners?$filter="+url.QueryEscape("CardType eq 'C' and not (CardName eq 'Acme')")+"&$top=1"))
+	require.Len(t, partners, 1)
+	assert.Equal(t, "Initech", partners[0].CardName)
+}
+
+func get[T any](t *testing.T, session *gosap.Session, endpoint string) []T {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	require.NoError(t, err)
+
+	_, content, err := session.Do(req)
+	require.NoError(t, err)
+
+	var res struct{ Value []T }
+	require.NoError(t, json.Unmarshal(content, &res))
+
+	return res.Value
+}
+
+func TestServerDocumentActions(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("DeliveryNotes", gosap.Document{
+		DocumentHeader: gosap.DocumentHeader{CardCode: "C001"},
+		DocumentLines:  []gosap.DocumentLine{{ItemCode: "A00001", Quantity: "2"}},
+	}))
+
+	session, cfg := newSession(t, server)
+
+	note, err := session.GetDeliveryNote(cfg, "1")
+	require.NoError(t, err)
+	assert.True(t, note.IsOpen())
+
+	require.NoError(t, session.CloseDeliveryNote(cfg, "1"))
+	require.Error(t, session.CloseDeliveryNote(cfg, "1"))
+
+	note, err = session.GetDeliveryNote(cfg, "1")
+	require.NoError(t, err)
+	assert.True(t, note.IsClosed())
+
+	require.NoError(t, session.RopenDeliveryNote(cfg, "1"))
+	require.NoError(t, session.CancelDeliveryNote(cfg, "1"))
+
+	note, err = session.GetDeliveryNote(cfg, "1")
+	require.NoError(t, err)
+	assert.True(t, note.IsCancelled())
+
+	_, err = session.GetDeliveryNote(cfg, "2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "-2028")
+}
+
+func TestServerCRUD(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	session, cfg := newSession(t, server)
+
+	created, err := session.CreateItem(cfg, gosap.Item{ItemCode: "A00001", ItemName: "Pallet"})
+	require.NoError(t, err)
+	assert.Equal(t, "Pallet", created.ItemName)
+
+	_, err = session.CreateItem(cfg, gosap.Item{ItemCode: "A00001"})
+	require.Error(t, err)
+
+	require.NoError(t, session.UpdateItem(cfg, "A00001", gosap.Item{ItemName: "Euro pallet"}))
+
+	var item gosap.Item
+	require.NoError(t, server.Load("Items", "'A00001'", &item))
+	assert.Equal(t, "Euro pallet", item.ItemName)
+
+	require.NoError(t, session.DeleteItem(cfg, "A00001"))
+	assert.Empty(t, server.Entities("Items"))
+
+	draft, err := session.CreateDraft(cfg, gosap.ObjectOrders, gosap.Document{
+		DocumentHeader: gosap.DocumentHeader{CardCode: "C001"},
+		DocumentLines:  []gosap.DocumentLine{{ItemCode: "A00001", Quantity: "1"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.SaveDraftToDocument(cfg, draft.DocEntry))
+
+	orders := server.Entities("Orders")
+	require.Len(t, orders, 1)
+	assert.Equal(t, "C001", orders[0]["CardCode"])
+}
+
+func TestServerETagConflict(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: "A00001", ItemName: "Pallet"}))
+
+	session, cfg := newSession(t, server)
+	other, _ := newSession(t, server)
+
+	_, err := session.GetItem(cfg, "A00001")
+	require.NoError(t, err)
+
+	_, err = other.GetItem(cfg, "A00001")
+	require.NoError(t, err)
+	require.NoError(t, other.UpdateItem(cfg, "A00001", gosap.Item{ItemName: "Euro pallet"}))
+
+	err = session.UpdateItem(cfg, "A00001", gosap.Item{ItemName: "Crate"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, gosap.ErrConflict))
+}
+
+func TestServerV2(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	server.PageSize = 2
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: fmt.Sprintf("A%05d", i)}))
+	}
+
+	cfg := server.Config()
+	cfg.Version = gosap.APIv2
+
+	var paths []string
+
+	cfg.Middleware = []gosap.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return gosap.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.Path)
+
+			return next.RoundTrip(req)
+		})
+	}}
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	items, err := session.GetItems(cfg)
+	require.NoError(t, err)
+	require.Len(t, items.Value, 5)
+	assert.Contains(t, items.Metadata, "/b1s/v2/$metadata#Items")
+
+	for _, path := range paths {
+		assert.True(t, strings.HasPrefix(path, "/b1s/v2/"), path)
+	}
+
+	_, err = session.GetItem(cfg, "B00001")
+	require.Error(t, err)
+}
+
+func TestServerBehindProxyPath(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	server.PageSize = 2
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: fmt.Sprintf("A%05d", i)}))
+	}
+
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = server.Client().Transport
+
+	front := httptest.NewTLSServer(http.StripPrefix("/erp", proxy))
+	defer front.Close()
+
+	cfg := server.Config()
+	cfg.BaseURL = front.URL + "/erp/b1s"
+
+	var hosts []string
+
+	cfg.Middleware = []gosap.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return gosap.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Host+req.URL.Path)
+
+			return next.RoundTrip(req)
+		})
+	}}
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	items, err := session.GetItems(cfg)
+	require.NoError(t, err)
+	require.Len(t, items.Value, 5)
+
+	prefix := strings.TrimPrefix(front.URL, "https://") + "/erp/b1s/v1/"
+	for _, host := range hosts {
+		assert.True(t, strings.HasPrefix(host, prefix), host)
+	}
+}
@@ -0,0 +1,547 @@
+package gosaptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/octomiro/gosap"
+)
+
+// entitySet holds the entities of one Service Layer entity set in insertion order.
+type entitySet struct {
+	name string
+	keys []string
+	// autoKey numbers the first key on creation when it is missing.
+	autoKey bool
+	// document sets the defaults and actions of marketing documents.
+	document bool
+	next     int
+	entities []*entity
+}
+
+type entity struct {
+	props   map[string]any
+	version int
+}
+
+func (e *entity) etag() string {
+	return fmt.Sprintf(`W/"%d"`, e.version)
+}
+
+// autoKeys are the key properties the Service Layer numbers itself.
+var autoKeys = map[string]bool{
+	"DocEntry":      true,
+	"DocumentEntry": true,
+	"AbsEntry":      true,
+	"PriceListNo":   true,
+}
+
+// lineKeys identify the lines of collections when a PATCH merges them. PriceList identifies
+// the prices of an item.
+var lineKeys = []string{"LineNum", "LineNumber", "RowNumber", "RowNum", "LineId", "InternalCode", "PriceList"}
+
+func newEntitySet(name string, keys ...string) *entitySet {
+	return &entitySet{name: name, keys: keys, autoKey: len(keys) == 1 && autoKeys[keys[0]], next: 1}
+}
+
+func newDocumentSet(name string) *entitySet {
+	set := newEntitySet(name, "DocEntry")
+	set.document = true
+
+	return set
+}
+
+func defaultEntitySets() map[string]*entitySet {
+	sets := map[string]*entitySet{}
+
+	for _, set := range []*entitySet{
+		newEntitySet("Items", "ItemCode"),
+		newEntitySet("BusinessPartners", "CardCode"),
+		newEntitySet("PriceLists", "PriceListNo"),
+		newEntitySet("SpecialPrices", "ItemCode", "CardCode"),
+		newEntitySet("InventoryCountings", "DocumentEntry"),
+		newEntitySet("BinLocations", "AbsEntry"),
+		newEntitySet("BinLocationFields", "AbsEntry"),
+		newEntitySet("BinLocationAttributes", "AbsEntry"),
+		newEntitySet("ApprovalRequests", "Code"),
+		newEntitySet("UserTablesMD", "TableName"),
+		newEntitySet("UserFieldsMD", "TableName", "FieldID"),
+		newEntitySet("UserObjectsMD", "Code"),
+		newDocumentSet("Drafts"),
+	} {
+		sets[set.name] = set
+	}
+
+	for _, kind := range documentKinds {
+		sets[kind.EntitySet] = newDocumentSet(kind.EntitySet)
+	}
+
+	return sets
+}
+
+var documentKinds = []gosap.DocumentKind{
+	gosap.KindQuotation, gosap.KindOrder, gosap.KindDeliveryNote, gosap.KindReturn, gosap.KindInvoice,
+	gosap.KindPurchaseRequest, gosap.KindPurchaseQuotation, gosap.KindPurchaseOrder,
+	gosap.KindPurchaseDeliveryNote, gosap.KindPurchaseReturn, gosap.KindPurchaseInvoice,
+}
+
+// decodeEntity reads a JSON object keeping numbers exact.
+func decodeEntity(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var props map[string]any
+	if err := dec.Decode(&props); err != nil {
+		return nil, fmt.Errorf("invalid entity: %s", err)
+	}
+
+	if props == nil {
+		return nil, fmt.Errorf("invalid entity: not an object")
+	}
+
+	return props, nil
+}
+
+// toEntity converts a gosap type, a map or raw JSON to entity properties.
+func toEntity(v any) (map[string]any, error) {
+	var data []byte
+
+	switch v := v.(type) {
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		var err error
+
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return decodeEntity(data)
+}
+
+// keyString renders a key value the same way whether it was decoded from JSON or from a URL.
+func keyString(v any) string {
+	return fmt.Sprint(v)
+}
+
+var keyPattern = regexp.MustCompile(`^(\w+)=(.+)$`)
+
+// parseKeys reads the key predicate of an entity URL, e.g. 'A00001', 12 or
+// ItemCode='A00001',CardCode='C20000'.
+func (set *entitySet) parseKeys(predicate string) (map[string]string, error) {
+	parts := splitKeys(predicate)
+	keys := map[string]string{}
+
+	for i, part := range parts {
+		name := ""
+		if m := keyPattern.FindStringSubmatch(part); m != nil && !strings.HasPrefix(part, "'") {
+			name, part = m[1], m[2]
+		} else if i < len(set.keys) {
+			name = set.keys[i]
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("invalid key %s for %s", predicate, set.name)
+		}
+
+		value := part
+		if strings.HasPrefix(part, "'") && strings.HasSuffix(part, "'") && len(part) >= 2 {
+			value = strings.ReplaceAll(part[1:len(part)-1], "''", "'")
+		}
+
+		keys[name] = value
+	}
+
+	if len(keys) != len(set.keys) {
+		return nil, fmt.Errorf("invalid key %s for %s", predicate, set.name)
+	}
+
+	return keys, nil
+}
+
+// splitKeys splits a key predicate on the commas outside of string literals.
+func splitKeys(predicate string) []string {
+	var (
+		parts    []string
+		current  strings.Builder
+		inString bool
+	)
+
+	for _, r := range predicate {
+		switch {
+		case r == '\'':
+			inString = !inString
+		case r == ',' && !inString:
+			parts = append(parts, current.String())
+			current.Reset()
+
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	return append(parts, current.String())
+}
+
+func (set *entitySet) find(keys map[string]string) (int, *entity) {
+	for i, e := range set.entities {
+		matches := true
+
+		for name, value := range keys {
+			if keyString(e.props[name]) != value {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return i, e
+		}
+	}
+
+	return -1, nil
+}
+
+func (set *entitySet) keysOf(props map[string]any) map[string]string {
+	keys := map[string]string{}
+	for _, name := range set.keys {
+		keys[name] = keyString(props[name])
+	}
+
+	return keys
+}
+
+// keyPredicate renders the key of props as it appears in an entity URL.
+func (set *entitySet) keyPredicate(props map[string]any) string {
+	literal := func(v any) string {
+		if s, ok := v.(string); ok {
+			return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+		}
+
+		return keyString(v)
+	}
+
+	if len(set.keys) == 1 {
+		return literal(props[set.keys[0]])
+	}
+
+	parts := make([]string, 0, len(set.keys))
+	for _, name := range set.keys {
+		parts = append(parts, name+"="+literal(props[name]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// create adds an entity, numbering it and filling the defaults SAP would.
+func (set *entitySet) create(props map[string]any) (*entity, error) {
+	if set.autoKey {
+		key := set.keys[0]
+		if props[key] == nil {
+			props[key] = json.Number(strconv.Itoa(set.next))
+		}
+
+		if n, err := strconv.Atoi(keyString(props[key])); err == nil && n >= set.next {
+			set.next = n + 1
+		}
+	}
+
+	if set.name == "UserFieldsMD" && props["FieldID"] == nil {
+		fieldID := 0
+
+		for _, e := range set.entities {
+			if e.props["TableName"] == props["TableName"] {
+				fieldID++
+			}
+		}
+
+		props["FieldID"] = json.Number(strconv.Itoa(fieldID))
+	}
+
+	for _, name := range set.keys {
+		if props[name] == nil || props[name] == "" {
+			return nil, fmt.Errorf("%s is required", name)
+		}
+	}
+
+	if _, existing := set.find(set.keysOf(props)); existing != nil {
+		return nil, fmt.Errorf("%s %s already exists", set.name, set.keyPredicate(props))
+	}
+
+	if set.document {
+		setDocumentDefaults(props)
+	}
+
+	if set.name == "InventoryCountings" {
+		setDefault(props, "DocumentNumber", props["DocumentEntry"])
+		setDefault(props, "DocumentStatus", string(gosap.CountingStatusOpen))
+		numberLines(props["InventoryCountingLines"], "LineNumber", 1)
+	}
+
+	e := &entity{props: props, version: 1}
+	set.entities = append(set.entities, e)
+
+	return e, nil
+}
+
+func setDefault(props map[string]any, name string, value any) {
+	if _, ok := props[name]; !ok {
+		props[name] = value
+	}
+}
+
+func setDocumentDefaults(props map[string]any) {
+	setDefault(props, "DocNum", props["DocEntry"])
+	setDefault(props, "DocType", string(gosap.DocumentTypeItems))
+	setDefault(props, "DocumentStatus", string(gosap.BoStatusOpen))
+	setDefault(props, "Cancelled", string(gosap.BoNo))
+
+	lines, _ := props["DocumentLines"].([]any)
+	numberLines(lines, "LineNum", 0)
+
+	for _, line := range lines {
+		if line, ok := line.(map[string]any); ok {
+			setDefault(line, "LineStatus", string(gosap.BoStatusOpen))
+			setDefault(line, "RemainingOpenQuantity", line["Quantity"])
+		}
+	}
+}
+
+// numberLines gives the lines of a collection without line key the next free one.
+func numberLines(collection any, key string, first int) {
+	lines, _ := collection.([]any)
+	next := first
+
+	for _, line := range lines {
+		if line, ok := line.(map[string]any); ok {
+			if n, err := strconv.Atoi(keyString(line[key])); err == nil && line[key] != nil && n >= next {
+				next = n + 1
+			}
+		}
+	}
+
+	for _, line := range lines {
+		if line, ok := line.(map[string]any); ok && line[key] == nil {
+			line[key] = json.Number(strconv.Itoa(next))
+			next++
+		}
+	}
+}
+
+// patch merges props into e. Collections replace the existing ones when replace is true and
+// are merged line by line otherwise.
+func (set *entitySet) patch(e *entity, props map[string]any, replace bool) error {
+	for name := range props {
+		for _, key := range set.keys {
+			if name == key && keyString(props[name]) != keyString(e.props[name]) {
+				return fmt.Errorf("%s can not be changed", name)
+			}
+		}
+	}
+
+	for name, value := range props {
+		lines, isCollection := value.([]any)
+		existing, hasExisting := e.props[name].([]any)
+
+		if !isCollection || !hasExisting || replace {
+			e.props[name] = value
+
+			continue
+		}
+
+		e.props[name] = mergeLines(existing, lines)
+	}
+
+	if set.document {
+		setDocumentDefaults(e.props)
+	}
+
+	if set.name == "InventoryCountings" {
+		numberLines(e.props["InventoryCountingLines"], "LineNumber", 1)
+	}
+
+	if set.name == "ApprovalRequests" {
+		decideApproval(e.props)
+	}
+
+	e.version++
+
+	return nil
+}
+
+// decideApproval sets the status of an approval request from its last decision, as a single
+// stage approval template does.
+func decideApproval(props map[string]any) {
+	decisions, _ := props["ApprovalRequestDecisions"].([]any)
+	if len(decisions) == 0 {
+		return
+	}
+
+	last, _ := decisions[len(decisions)-1].(map[string]any)
+
+	switch last["Status"] {
+	case string(gosap.ApprovalDecisionApproved):
+		props["Status"] = string(gosap.ApprovalRequestApproved)
+	case string(gosap.ApprovalDecisionNotApproved):
+		props["Status"] = string(gosap.ApprovalRequestNotApproved)
+	}
+}
+
+// mergeLines updates the existing lines that patched lines identify by their line key and adds
+// the others.
+func mergeLines(existing, patched []any) []any {
+	for _, line := range patched {
+		patchedLine, ok := line.(map[string]any)
+		if !ok {
+			existing = append(existing, line)
+
+			continue
+		}
+
+		merged := false
+
+		for _, key := range lineKeys {
+			id, ok := patchedLine[key]
+			if !ok {
+				continue
+			}
+
+			for _, current := range existing {
+				if currentLine, ok := current.(map[string]any); ok && keyString(currentLine[key]) == keyString(id) {
+					for name, value := range patchedLine {
+						currentLine[name] = value
+					}
+
+					merged = true
+				}
+			}
+
+			break
+		}
+
+		if !merged {
+			existing = append(existing, patchedLine)
+		}
+	}
+
+	return existing
+}
+
+// action runs a document action such as Close, Cancel or Reopen.
+func (set *entitySet) action(e *entity, name string) error {
+	if set.name == "InventoryCountings" && name == "Close" {
+		if e.props["DocumentStatus"] == string(gosap.CountingStatusClosed) {
+			return fmt.Errorf("document is already closed")
+		}
+
+		e.props["DocumentStatus"] = string(gosap.CountingStatusClosed)
+		e.version++
+
+		return nil
+	}
+
+	// Cancel is accepted on items without modelling its effect in SAP.
+	if set.name == "Items" && name == "Cancel" {
+		e.version++
+
+		return nil
+	}
+
+	if !set.document {
+		return fmt.Errorf("action %s is not supported on %s", name, set.name)
+	}
+
+	status, lineStatus := gosap.BoStatusClose, gosap.BoStatusClose
+
+	switch name {
+	case "Close":
+		if e.props["DocumentStatus"] != string(gosap.BoStatusOpen) {
+			return fmt.Errorf("document is already closed")
+		}
+	case "Cancel":
+		if e.props["Cancelled"] == string(gosap.BoYes) {
+			return fmt.Errorf("document is already cancelled")
+		}
+
+		e.props["Cancelled"] = string(gosap.BoYes)
+	case "Reopen":
+		if e.props["DocumentStatus"] != string(gosap.BoStatusClose) || e.props["Cancelled"] == string(gosap.BoYes) {
+			return fmt.Errorf("only closed documents can be reopened")
+		}
+
+		status, lineStatus = gosap.BoStatusOpen, gosap.BoStatusOpen
+	default:
+		return fmt.Errorf("action %s is not supported on %s", name, set.name)
+	}
+
+	e.props["DocumentStatus"] = string(status)
+
+	lines, _ := e.props["DocumentLines"].([]any)
+	for _, line := range lines {
+		if line, ok := line.(map[string]any); ok {
+			line["LineStatus"] = string(lineStatus)
+		}
+	}
+
+	e.version++
+
+	return nil
+}
+
+// query returns the entities matching the $filter and $orderby of options.
+func (set *entitySet) query(options url.Values) ([]map[string]any, error) {
+	var match filter
+
+	if expr := options.Get("$filter"); expr != "" {
+		var err error
+
+		match, err = parseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]map[string]any, 0, len(set.entities))
+
+	for _, e := range set.entities {
+		if match != nil {
+			ok, err := truthy(match(e.props))
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		res = append(res, e.props)
+	}
+
+	if spec := options.Get("$orderby"); spec != "" {
+		orderBy(res, spec)
+	}
+
+	return res, nil
+}
+
+// clone deep-copies props so callers can't change the store behind its back.
+func clone(props map[string]any) map[string]any {
+	data, _ := json.Marshal(props)
+	copied, _ := decodeEntity(data)
+
+	return copied
+}
@@ -0,0 +1,60 @@
+package gosap_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeactivateItem(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: "A00001", Valid: gosap.BoYes, Frozen: gosap.BoNo}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.DeactivateItem(cfg, "A00001"))
+
+	patch := lastRequest(t, sent(), http.MethodPatch)
+	assert.Equal(t, "/b1s/v1/Items('A00001')", patch.Path)
+	assert.Equal(t, map[string]any{"Valid": "tNO", "Frozen": "tYES"}, patch.Body)
+
+	item, err := session.GetItem(cfg, "A00001", "ItemCode", "Valid", "Frozen")
+	require.NoError(t, err)
+	assert.Equal(t, gosap.BoNo, item.Valid)
+	assert.Equal(t, gosap.BoYes, item.Frozen)
+}
+
+func TestCancelItem(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: "A00001"}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.CancelItem(cfg, "A00001"))
+
+	post := lastRequest(t, sent(), http.MethodPost)
+	assert.Equal(t, "/b1s/v1/Items('A00001')/Cancel", post.Path)
+	assert.Nil(t, post.Body)
+
+	require.Error(t, session.CancelItem(cfg, "A99999"))
+}
@@ -0,0 +1,123 @@
+package gosap
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redacted replaces secrets in logs.
+const redacted = "REDACTED"
+
+// loggingMiddleware logs every request with its operation, method, path, status, duration,
+// collection page and SAP error code. Successful requests are logged at debug level, refused
+// ones at warn level and failed ones at error level. Bodies, query strings and headers are
+// never logged, so the login payload and session cookies stay out of the logs.
+func loggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+
+			resp, err := next.RoundTrip(req)
+
+			attrs := []slog.Attr{
+				slog.String("operation", OperationOf(req)),
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			if page := PageOf(req); page > 0 {
+				attrs = append(attrs, slog.Int("page", page))
+			}
+
+			ctx := req.Context()
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "sap request failed", attrs...)
+
+				return nil, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+			if resp.StatusCode < http.StatusBadRequest {
+				logger.LogAttrs(ctx, slog.LevelDebug, "sap request", attrs...)
+
+				return resp, nil
+			}
+
+			if code, message := serviceLayerError(resp); code != "" {
+				attrs = append(attrs, slog.String("sap_error_code", code), slog.String("sap_error", message))
+			}
+
+			logger.LogAttrs(ctx, slog.LevelWarn, "sap request refused", attrs...)
+
+			return resp, nil
+		})
+	}
+}
+
+// serviceLayerError reads the code and message of the error body of resp, leaving the body
+// readable by the caller.
+func serviceLayerError(resp *http.Response) (code, message string) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return "", ""
+	}
+
+	return parseServiceLayerError(body)
+}
+
+// parseServiceLayerError reads the code and message of a Service Layer error body.
+// The message is an object holding the value in v1 and a plain string in v2.
+func parseServiceLayerError(body []byte) (code, message string) {
+	var payload struct {
+		Error struct {
+			Code    json.RawMessage `json:"code"`
+			Message json.RawMessage `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", ""
+	}
+
+	var v1 struct {
+		Value string `json:"value"`
+	}
+
+	if err := json.Unmarshal(payload.Error.Message, &v1); err == nil {
+		message = v1.Value
+	} else {
+		_ = json.Unmarshal(payload.Error.Message, &message)
+	}
+
+	return strings.Trim(string(payload.Error.Code), `"`), message
+}
+
+// LogValue logs the config without its password.
+func (c Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("url", c.ServiceURL()),
+		slog.String("company_db", c.CompanyDB),
+		slog.String("username", c.Username),
+		slog.String("password", redacted),
+	)
+}
+
+// LogValue logs the session without its B1SESSION cookie.
+func (s *Session) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("b1session", redacted),
+		slog.String("route_id", s.RouteID),
+	)
+}
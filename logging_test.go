@@ -0,0 +1,74 @@
+package gosap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerLogsRequests(t *testing.T) {
+	t.Parallel()
+
+	cfg := serviceLayerStub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/b1s/v1/Login":
+			http.SetCookie(w, &http.Cookie{Name: "B1SESSION", Value: "secret-session"})
+		case r.URL.Query().Get("$skip") == "":
+			_, _ = w.Write([]byte(`{"value":[{"AbsEntry":1}],"odata.nextLink":"/b1s/v1/BinLocations?$skip=1"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":-1,"message":{"lang":"en-us","value":"Internal error"}}}`))
+		}
+	})
+	cfg.CompanyDB = "SBODEMO"
+	cfg.Username = "manager"
+	cfg.Password = "secret-password"
+
+	var logs bytes.Buffer
+	cfg.Logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	_, err = session.GetBinLocations(cfg)
+	require.Error(t, err)
+
+	cfg.Logger.Info("configured", "config", cfg, "session", session)
+
+	assert.NotContains(t, logs.String(), "secret-password")
+	assert.NotContains(t, logs.String(), "secret-session")
+
+	var records []map[string]any
+
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		records = append(records, record)
+	}
+
+	require.Len(t, records, 4)
+
+	assert.Equal(t, "Authenticate", records[0]["operation"])
+	assert.Equal(t, "/b1s/v1/Login", records[0]["path"])
+	assert.EqualValues(t, http.StatusOK, records[0]["status"])
+
+	assert.Equal(t, "GetBinLocations", records[1]["operation"])
+	assert.EqualValues(t, 1, records[1]["page"])
+
+	assert.Equal(t, "WARN", records[2]["level"])
+	assert.EqualValues(t, 2, records[2]["page"])
+	assert.EqualValues(t, http.StatusBadRequest, records[2]["status"])
+	assert.Equal(t, "-1", records[2]["sap_error_code"])
+	assert.Contains(t, records[2], "duration")
+
+	assert.Equal(t, "REDACTED", records[3]["config"].(map[string]any)["password"])
+	assert.Equal(t, "REDACTED", records[3]["session"].(map[string]any)["b1session"])
+}
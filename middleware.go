@@ -0,0 +1,158 @@
+package gosap
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Middleware wraps the transport of a session, e.g. to add headers, log or measure every
+// Service Layer call. The operation of a request is available with OperationOf.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Hooks are callbacks run around every Service Layer call. Any of them can be nil.
+type Hooks struct {
+	// BeforeRequest runs before the request is sent and may change its headers.
+	BeforeRequest func(op string, req *http.Request)
+	// AfterResponse runs once a response is received, whatever its status.
+	AfterResponse func(op string, req *http.Request, resp *http.Response)
+	// OnError runs when the request could not be sent or no response was received.
+	OnError func(op string, req *http.Request, err error)
+}
+
+// Middleware returns the hooks as a middleware.
+func (h Hooks) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			op := OperationOf(req)
+
+			if h.BeforeRequest != nil {
+				req = req.Clone(req.Context())
+				h.BeforeRequest(op, req)
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				if h.OnError != nil {
+					h.OnError(op, req, err)
+				}
+
+				return nil, err
+			}
+
+			if h.AfterResponse != nil {
+				h.AfterResponse(op, req, resp)
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+type operationKey struct{}
+
+// WithOperation names the operation requests sent with ctx belong to. Sessions name requests
+// after the method sending them, e.g. GetPurchaseOrder, unless ctx already names one.
+func WithOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// Operation returns the operation named in ctx, if any.
+func Operation(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(string)
+
+	return op
+}
+
+// OperationOf returns the operation req belongs to, e.g. GetPurchaseOrder or Authenticate.
+func OperationOf(req *http.Request) string {
+	return Operation(req.Context())
+}
+
+// withOperation names the operation of req after the exported gosap method or function it is
+// sent from, unless its context already names one.
+func withOperation(req *http.Request) *http.Request {
+	if Operation(req.Context()) != "" {
+		return req
+	}
+
+	op := callerOperation()
+	if op == "" {
+		return req
+	}
+
+	return req.WithContext(WithOperation(req.Context(), op))
+}
+
+type pageKey struct{}
+
+// PageOf returns the number, counting from 1, of the collection page req fetches while
+// following odata.nextLink, or 0 when it doesn't fetch a page.
+func PageOf(req *http.Request) int {
+	page, _ := req.Context().Value(pageKey{}).(int)
+
+	return page
+}
+
+// newPageRequest builds the GET request of page of a collection, 0 for a single entity.
+func newPageRequest(endpoint string, page int) (*http.Request, error) {
+	ctx := context.Background()
+	if page > 0 {
+		ctx = context.WithValue(ctx, pageKey{}, page)
+	}
+
+	return http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+}
+
+var packagePath = reflect.TypeOf((*Session)(nil)).Elem().PkgPath()
+
+// callerOperation returns the outermost exported function or Session method of gosap in the
+// innermost run of gosap calls on the stack, skipping Do itself. Stopping at the first caller
+// outside gosap keeps callbacks, e.g. of RetryOnConflict, named after what they call.
+func callerOperation() string {
+	// Page walks recurse once per page, so the stack can be deep.
+	pcs := make([]uintptr, 64)
+
+	n := runtime.Callers(3, pcs)
+	for n == len(pcs) {
+		pcs = make([]uintptr, 2*len(pcs))
+		n = runtime.Callers(3, pcs)
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+
+	op := ""
+
+	for {
+		frame, more := frames.Next()
+
+		name, ok := strings.CutPrefix(frame.Function, packagePath+".")
+		if !ok {
+			return op
+		}
+
+		name = strings.TrimPrefix(name, "(*Session).")
+		if i := strings.IndexAny(name, ".["); i >= 0 {
+			name = name[:i]
+		}
+
+		if r, _ := utf8.DecodeRuneInString(name); unicode.IsUpper(r) && name != "Do" {
+			op = name
+		}
+
+		if !more {
+			return op
+		}
+	}
+}
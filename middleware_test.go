@@ -0,0 +1,98 @@
+package gosap_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareSeesOperations(t *testing.T) {
+	t.Parallel()
+
+	var correlation []string
+
+	cfg := serviceLayerStub(t, func(w http.ResponseWriter, r *http.Request) {
+		correlation = append(correlation, r.Header.Get("X-Correlation-ID"))
+
+		switch r.URL.Path {
+		case "/b1s/v1/Login":
+			http.SetCookie(w, &http.Cookie{Name: "B1SESSION", Value: "session"})
+		case "/b1s/v1/Items('A00001')":
+			_, _ = w.Write([]byte(`{"ItemCode":"A00001","ItemPrices":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var (
+		mu         sync.Mutex
+		operations []string
+		statuses   []int
+	)
+
+	cfg.Middleware = []gosap.Middleware{
+		gosap.Hooks{
+			BeforeRequest: func(op string, req *http.Request) {
+				req.Header.Set("X-Correlation-ID", "corr-"+op)
+			},
+			AfterResponse: func(op string, _ *http.Request, resp *http.Response) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				operations = append(operations, op)
+				statuses = append(statuses, resp.StatusCode)
+			},
+		}.Middleware(),
+	}
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	_, err = session.GetItemPrices(cfg, "A00001")
+	require.NoError(t, err)
+
+	_, err = session.GetPriceList(cfg, 1)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"Authenticate", "GetItemPrices", "GetPriceList"}, operations)
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusNotFound}, statuses)
+	assert.Equal(t, []string{"corr-Authenticate", "corr-GetItemPrices", "corr-GetPriceList"}, correlation)
+}
+
+func TestMiddlewareOrderAndErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+
+	failing := errors.New("network down")
+
+	cfg := gosap.Config{IP: "sap.local", Port: gosap.B1DeaultPort}
+	cfg.Transport = gosap.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		calls = append(calls, "transport")
+
+		return nil, failing
+	})
+	cfg.Middleware = []gosap.Middleware{
+		func(next http.RoundTripper) http.RoundTripper {
+			return gosap.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, "outer")
+
+				return next.RoundTrip(req)
+			})
+		},
+		gosap.Hooks{
+			OnError: func(op string, _ *http.Request, err error) {
+				calls = append(calls, op+": "+err.Error())
+			},
+		}.Middleware(),
+	}
+
+	_, err := gosap.Authenticate(cfg)
+	require.Error(t, err)
+	assert.Equal(t, []string{"outer", "transport", "Authenticate: network down"}, calls)
+}
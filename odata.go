@@ -0,0 +1,62 @@
+package gosap
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// v2Annotations maps the OData v4 annotations of the v2 API to their v1 names, which the
+// response types are decoded with.
+var v2Annotations = map[string]string{
+	"@odata.context":  "odata.metadata",
+	"@odata.nextLink": "odata.nextLink",
+	"@odata.count":    "odata.count",
+}
+
+// normalizeAnnotations renames the v2 annotations at the top of a response body to their v1
+// names, so both API versions decode into the same types. Only those keys are rewritten: the
+// rest of the body, including annotations of nested entities, is kept byte for byte, and
+// bodies without top-level annotations are returned as is.
+func normalizeAnnotations(content []byte) []byte {
+	if !bytes.Contains(content, []byte(`"@odata.`)) {
+		return content
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return content
+	}
+
+	var normalized []byte
+
+	last := 0
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return content
+		}
+
+		key, _ := tok.(string)
+		end := int(dec.InputOffset())
+
+		// The annotations hold no escapes, so they end the key token as written.
+		if v1, ok := v2Annotations[key]; ok && bytes.HasSuffix(content[:end], []byte(`"`+key+`"`)) {
+			start := end - len(key) - 2
+			normalized = append(normalized, content[last:start]...)
+			normalized = append(normalized, `"`+v1+`"`...)
+			last = end
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return content
+		}
+	}
+
+	if normalized == nil {
+		return content
+	}
+
+	return append(normalized, content[last:]...)
+}
@@ -0,0 +1,52 @@
+package gosap_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2AnnotationsNormalized(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name, body, want string
+	}{
+		{
+			name: "top-level annotations",
+			body: `{"@odata.context":"$metadata#Items", "value":[], "@odata.nextLink":"Items?$skip=20"}`,
+			want: `{"odata.metadata":"$metadata#Items", "value":[], "odata.nextLink":"Items?$skip=20"}`,
+		},
+		{
+			name: "nested annotations are kept",
+			body: `{"@odata.context":"$metadata#Items","value":[{"@odata.etag":"W/\"1\"","ItemName":"<Pallet>"}]}`,
+			want: `{"odata.metadata":"$metadata#Items","value":[{"@odata.etag":"W/\"1\"","ItemName":"<Pallet>"}]}`,
+		},
+		{
+			name: "entity without top-level annotations",
+			body: `{"ItemCode":"A00001","Remarks":"see \"@odata.context\"","Lines":[{"@odata.count":1}]}`,
+			want: `{"ItemCode":"A00001","Remarks":"see \"@odata.context\"","Lines":[{"@odata.count":1}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := serviceLayerStub(t, func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, tt.body)
+			})
+
+			req, err := http.NewRequest(http.MethodGet, cfg.GetItemsEndpoint(), nil)
+			require.NoError(t, err)
+
+			_, content, err := (&gosap.Session{}).Do(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package gosap
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Patch is a partial update of an entity. Only the properties set on it are sent, with the
+// value given, so zero values and explicit nulls reach SAP where the omitempty entity types
+// would drop them.
+//
+// Collections, e.g. document lines, are merged by default: lines carrying their line number
+// are updated, lines without one are added and lines left out are kept. ReplaceCollections
+// makes the Service Layer replace them instead.
+type Patch struct {
+	members            []patchMember
+	replaceCollections bool
+	// unconditional sends the patch without If-Match, for changes that can't conflict.
+	unconditional bool
+}
+
+type patchMember struct {
+	name  string
+	value any
+}
+
+func NewPatch() *Patch {
+	return &Patch{}
+}
+
+// Set sends value for the property, replacing any earlier value. A nil value is sent as null.
+// Values can be nested patches, e.g. for the lines of a collection.
+func (p *Patch) Set(name string, value any) *Patch {
+	for i := range p.members {
+		if p.members[i].name == name {
+			p.members[i].value = value
+
+			return p
+		}
+	}
+
+	p.members = append(p.members, patchMember{name: name, value: value})
+
+	return p
+}
+
+// SetNull sends an explicit null for the property, clearing it in SAP.
+func (p *Patch) SetNull(name string) *Patch {
+	return p.Set(name, nil)
+}
+
+// Delete removes the property from the patch so it is left untouched.
+func (p *Patch) Delete(name string) *Patch {
+	p.members = slices.DeleteFunc(p.members, func(m patchMember) bool { return m.name == name })
+
+	return p
+}
+
+func (p *Patch) Has(name string) bool {
+	return slices.ContainsFunc(p.members, func(m patchMember) bool { return m.name == name })
+}
+
+// Fields lists the properties of the patch in the order they were set.
+func (p *Patch) Fields() []string {
+	fields := make([]string, 0, len(p.members))
+	for _, m := range p.members {
+		fields = append(fields, m.name)
+	}
+
+	return fields
+}
+
+// ReplaceCollections sends the patch with B1S-ReplaceCollectionsOnPatch, so the collections it
+// holds replace the existing ones: lines left out are removed.
+func (p *Patch) ReplaceCollections() *Patch {
+	p.replaceCollections = true
+
+	return p
+}
+
+func (p *Patch) MarshalJSON() ([]byte, error) {
+	members := make([]objectMember, 0, len(p.members))
+
+	for _, m := range p.members {
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode property %s due to %s", m.name, err)
+		}
+
+		members = append(members, objectMember{Key: m.name, Value: value})
+	}
+
+	return encodeObject(members), nil
+}
+
+// PatchFields builds a patch of the named JSON properties of entity, e.g. "CountedQuantity" of
+// an InventoryCountingLine, sending them even when they are zero. Names starting with U_ that no
+// struct field maps are taken from the user fields of entity and sent as null when it doesn't
+// have them.
+func PatchFields(entity any, fields ...string) (*Patch, error) {
+	rv := reflect.ValueOf(entity)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can not patch fields of %T", entity)
+	}
+
+	values, udfs := jsonFields(rv)
+	patch := NewPatch()
+
+	for _, name := range fields {
+		if value, ok := values[name]; ok {
+			patch.Set(name, value.Interface())
+
+			continue
+		}
+
+		// User fields without a struct field of their own live in UserFields.
+		if !strings.HasPrefix(name, UserFieldPrefix) || !udfs.IsValid() {
+			return nil, fmt.Errorf("%T has no property %s", entity, name)
+		}
+
+		raw, ok := udfs.Interface().(UserFields)[name]
+		if !ok {
+			raw = json.RawMessage("null")
+		}
+
+		patch.Set(name, raw)
+	}
+
+	return patch, nil
+}
+
+// PatchEntity sends patch to the entity at endpoint, e.g. cfg.GetInventoryCountingEndpoint(id)
+// or cfg.GetBusinessPartnerEndpoint(cardCode).
+func (s *Session) PatchEntity(endpoint string, patch *Patch) error {
+	if err := updateDocument(s, endpoint, patch); err != nil {
+		return fmt.Errorf("could not patch %s due to %w", endpoint, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package gosap_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchMarshal(t *testing.T) {
+	t.Parallel()
+
+	line := gosap.NewPatch().Set("LineNumber", 3).Set("CountedQuantity", gosap.Zero)
+	patch := gosap.NewPatch().
+		Set("Remarks", "").
+		SetNull("U_Checked").
+		Set("InventoryCountingLines", []*gosap.Patch{line}).
+		Set("Remarks", "recount")
+
+	out, err := json.Marshal(patch)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"Remarks":"recount","U_Checked":null,"InventoryCountingLines":[{"LineNumber":3,"CountedQuantity":0}]}`,
+		string(out))
+	assert.Equal(t, []string{"Remarks", "U_Checked", "InventoryCountingLines"}, patch.Fields())
+
+	patch.Delete("U_Checked")
+	assert.False(t, patch.Has("U_Checked"))
+}
+
+func TestPatchFields(t *testing.T) {
+	t.Parallel()
+
+	line := gosap.InventoryCountingLine{LineNum: 2, CountedQuantity: gosap.Zero, BinEntry: 5}
+	require.NoError(t, line.UserFields.Set("Counter", "sami"))
+
+	patch, err := gosap.PatchFields(&line, "LineNumber", "CountedQuantity", "U_Counter", "U_Missing")
+	require.NoError(t, err)
+
+	out, err := json.Marshal(patch)
+	require.NoError(t, err)
+	assert.Equal(t, `{"LineNumber":2,"CountedQuantity":0,"U_Counter":"sami","U_Missing":null}`, string(out))
+
+	_, err = gosap.PatchFields(line, "Quantity")
+	assert.Error(t, err)
+}
+
+func TestPatchFieldsMappedUserField(t *testing.T) {
+	t.Parallel()
+
+	note := gosap.DeliveryNote{PlateNum: "123TU4567"}
+	require.NoError(t, note.UserFields.Set("Driver", "Sami"))
+
+	patch, err := gosap.PatchFields(note, "U_PlateNum", "U_Driver")
+	require.NoError(t, err)
+
+	out, err := json.Marshal(patch)
+	require.NoError(t, err)
+	assert.Equal(t, `{"U_PlateNum":"123TU4567","U_Driver":"Sami"}`, string(out))
+}
+
+func TestPatchInventoryCounting(t *testing.T) {
+	t.Parallel()
+
+	var (
+		method, path, replace string
+		body                  []byte
+	)
+
+	cfg := serviceLayerStub(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		replace = r.Header.Get("B1S-ReplaceCollectionsOnPatch")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	session := &gosap.Session{}
+	patch := gosap.NewPatch().SetNull("Remarks").ReplaceCollections()
+	require.NoError(t, session.PatchInventoryCounting(cfg, 12, patch))
+
+	assert.Equal(t, http.MethodPatch, method)
+	assert.Equal(t, "/b1s/v1/InventoryCountings(12)", path)
+	assert.Equal(t, "true", replace)
+	assert.JSONEq(t, `{"Remarks":null}`, string(body))
+
+	_, err := session.AddLinesToInventoryCounting(cfg, 12, []gosap.InventoryCountingLine{{ItemCode: "A00001"}})
+	require.NoError(t, err)
+	assert.Empty(t, replace)
+	assert.JSONEq(t, `{"InventoryCountingLines":[{"ItemCode":"A00001"}]}`, string(body))
+}
+
+func TestPatchItem(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("Items", gosap.Item{
+		ItemCode: "A00001", SalesUnitWeight: "2",
+		ItemPrices: []gosap.ItemPrice{{PriceList: 1, Price: "10"}, {PriceList: 2, Price: "20"}},
+	}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.PatchItem(cfg, "A00001", gosap.NewPatch().Set("SalesUnitWeight", gosap.Zero)))
+	assert.Equal(t, map[string]any{"SalesUnitWeight": float64(0)}, lastRequest(t, sent(), http.MethodPatch).Body)
+
+	require.NoError(t, session.UpdateItemPrices(cfg, "A00001", []gosap.ItemPrice{{PriceList: 1, Price: gosap.Zero}}))
+	assert.Equal(t, map[string]any{"ItemPrices": []any{
+		map[string]any{"PriceList": float64(1), "Price": float64(0)},
+	}}, lastRequest(t, sent(), http.MethodPatch).Body)
+
+	item, err := session.GetItem(cfg, "A00001", "*")
+	require.NoError(t, err)
+	assert.True(t, item.SalesUnitWeight.IsZero())
+
+	prices, err := session.GetItemPrices(cfg, "A00001")
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.True(t, prices[0].Price.IsZero())
+	assert.Equal(t, gosap.Decimal("20"), prices[1].Price)
+}
+
+func TestPatchBusinessPartner(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("BusinessPartners", gosap.BusinessPartner{CardCode: "C20000", CreditLimit: "5000"}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.PatchBusinessPartner(cfg, "C20000", gosap.NewPatch().Set("CreditLimit", gosap.Zero)))
+	assert.Equal(t, map[string]any{"CreditLimit": float64(0)}, lastRequest(t, sent(), http.MethodPatch).Body)
+
+	partner, err := session.GetBusinessPartner(cfg, "C20000")
+	require.NoError(t, err)
+	assert.True(t, partner.CreditLimit.IsZero())
+}
+
+func TestPatchPriceList(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("PriceLists", gosap.PriceList{PriceListNo: 3, PriceListName: "Retail", Factor: "1.2"}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, session.PatchPriceList(cfg, 3, gosap.NewPatch().Set("Factor", gosap.Zero)))
+	assert.Equal(t, map[string]any{"Factor": float64(0)}, lastRequest(t, sent(), http.MethodPatch).Body)
+
+	list, err := session.GetPriceList(cfg, 3)
+	require.NoError(t, err)
+	assert.True(t, list.Factor.IsZero())
+	assert.Equal(t, "Retail", list.PriceListName)
+}
+
+func TestPatchSpecialPrice(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("SpecialPrices", gosap.SpecialPrice{
+		ItemCode: "A00001", CardCode: "C20000", Price: "80", DiscountPercent: "20",
+	}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	patch := gosap.NewPatch().Set("DiscountPercent", gosap.Zero)
+	require.NoError(t, session.PatchSpecialPrice(cfg, "A00001", "C20000", patch))
+	assert.Equal(t, map[string]any{"DiscountPercent": float64(0)}, lastRequest(t, sent(), http.MethodPatch).Body)
+
+	price, err := session.GetSpecialPrice(cfg, "A00001", "C20000")
+	require.NoError(t, err)
+	assert.True(t, price.DiscountPercent.IsZero())
+	assert.Equal(t, gosap.Decimal("80"), price.Price)
+}
+
+func TestPatchInventoryCountingToZero(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("InventoryCountings", gosap.InventoryCounting{
+		DocumentEntry:          7,
+		InventoryCountingLines: []gosap.InventoryCountingLine{{LineNum: 1, ItemCode: "A00001", CountedQuantity: "5"}},
+	}))
+
+	cfg := server.Config()
+	sent := captureRequests(t, &cfg)
+
+	session, err := gosap.Authenticate(cfg)
+	require.NoError(t, err)
+
+	line, err := gosap.PatchFields(gosap.InventoryCountingLine{LineNum: 1}, "LineNumber", "CountedQuantity")
+	require.NoError(t, err)
+	require.NoError(t, session.PatchInventoryCounting(cfg, 7,
+		gosap.NewPatch().Set("InventoryCountingLines", []*gosap.Patch{line})))
+
+	assert.Equal(t, map[string]any{"InventoryCountingLines": []any{
+		map[string]any{"LineNumber": float64(1), "CountedQuantity": float64(0)},
+	}}, lastRequest(t, sent(), http.MethodPatch).Body)
+
+	counting, err := session.GetInventoryCounting(cfg, 7)
+	require.NoError(t, err)
+	require.Len(t, counting.InventoryCountingLines, 1)
+	assert.True(t, counting.InventoryCountingLines[0].CountedQuantity.IsZero())
+}
@@ -0,0 +1,159 @@
+package gosap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPoolClosed is returned by a Pool once it is closed.
+var ErrPoolClosed = errors.New("session pool is closed")
+
+// Pool holds sessions logged in to the same company, so work sent in parallel isn't serialized
+// on a single B1SESSION. Each session keeps its own ROUTEID, so its requests stay on the node
+// behind the load balancer it logged in on.
+//
+//	pool, err := gosap.NewPool(cfg, 4)
+//	defer pool.Close()
+//
+//	err = pool.Do(ctx, func(session *gosap.Session) error {
+//		order, err := session.GetPurchaseOrder(cfg, "12")
+//		...
+//	})
+type Pool struct {
+	cfg Config
+	// idle hands sessions out in the order they were asked for: goroutines waiting on a
+	// channel receive in turn.
+	idle chan *Session
+	size int
+	done chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewPool logs size sessions in with cfg.
+func NewPool(cfg Config, size int) (*Pool, error) {
+	size = max(size, 1)
+
+	p := &Pool{
+		cfg:  cfg,
+		idle: make(chan *Session, size),
+		size: size,
+		done: make(chan struct{}),
+	}
+
+	sessions := make([]*Session, size)
+	errs := make([]error, size)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < size; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			sessions[i], errs[i] = Authenticate(cfg)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		for _, session := range sessions {
+			if session != nil {
+				_ = session.Logout(cfg)
+			}
+		}
+
+		return nil, fmt.Errorf("could not fill session pool due to %w", err)
+	}
+
+	for _, session := range sessions {
+		p.idle <- session
+	}
+
+	return p, nil
+}
+
+// Acquire waits for an idle session and returns it sending its requests with ctx. A session
+// that expired is logged in again first. The session must be given back with Release.
+func (p *Pool) Acquire(ctx context.Context) (*Session, error) {
+	select {
+	case <-p.done:
+		return nil, ErrPoolClosed
+	default:
+	}
+
+	var session *Session
+
+	select {
+	case session = <-p.idle:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.done:
+		return nil, ErrPoolClosed
+	}
+
+	if session.Expired() {
+		if err := session.WithContext(ctx).Relogin(p.cfg); err != nil {
+			p.idle <- session
+
+			return nil, err
+		}
+	}
+
+	return session.WithContext(ctx), nil
+}
+
+// Release gives a session returned by Acquire back to the pool.
+func (p *Pool) Release(session *Session) {
+	p.idle <- session.root()
+}
+
+// Do runs fn with a session of the pool. When fn fails because the session expired meanwhile,
+// it logs the session in again and runs fn once more, so fn should be safe to run again.
+func (p *Pool) Do(ctx context.Context, fn func(session *Session) error) error {
+	session, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer p.Release(session)
+
+	err = fn(session)
+	if err == nil || !session.Expired() {
+		return err
+	}
+
+	if err := session.Relogin(p.cfg); err != nil {
+		return err
+	}
+
+	return fn(session)
+}
+
+// Close waits for the sessions in use to be released and logs all of them out, freeing their
+// license seats. Acquire fails with ErrPoolClosed once Close is called.
+func (p *Pool) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+
+		var errs []error
+
+		for i := 0; i < p.size; i++ {
+			session := <-p.idle
+
+			// A session the Service Layer already ended needs no logout.
+			if err := session.Logout(p.cfg); err != nil && !session.Expired() {
+				errs = append(errs, err)
+			}
+		}
+
+		p.closeErr = errors.Join(errs...)
+	})
+
+	return p.closeErr
+}
@@ -0,0 +1,161 @@
+package gosap_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolSpreadsWorkOverSessions(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	server.Nodes = 2
+	require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: "A00001"}))
+
+	cfg := server.Config()
+
+	pool, err := gosap.NewPool(cfg, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, server.Sessions())
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		routes = map[string]bool{}
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := pool.Do(context.Background(), func(session *gosap.Session) error {
+				mu.Lock()
+				routes[session.RouteID] = true
+				mu.Unlock()
+
+				_, err := session.GetItem(cfg, "A00001")
+
+				return err
+			})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, map[string]bool{".node0": true, ".node1": true}, routes)
+
+	require.NoError(t, pool.Close())
+	assert.Zero(t, server.Sessions())
+
+	_, err = pool.Acquire(context.Background())
+	require.ErrorIs(t, err, gosap.ErrPoolClosed)
+}
+
+func TestPoolRefreshesExpiredSessions(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	require.NoError(t, server.Seed("Items", gosap.Item{ItemCode: "A00001"}))
+
+	cfg := server.Config()
+
+	pool, err := gosap.NewPool(cfg, 2)
+	require.NoError(t, err)
+
+	server.ExpireSessions()
+
+	runs := 0
+	err = pool.Do(context.Background(), func(session *gosap.Session) error {
+		runs++
+		_, err := session.GetItem(cfg, "A00001")
+
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, 1, server.Sessions())
+
+	session, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+
+	_, err = session.GetItem(cfg, "A00001")
+	require.Error(t, err)
+	assert.True(t, session.Expired())
+
+	pool.Release(session)
+
+	session, err = pool.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, session.Expired())
+
+	_, err = session.GetItem(cfg, "A00001")
+	require.NoError(t, err)
+
+	pool.Release(session)
+
+	require.NoError(t, pool.Close())
+	assert.Zero(t, server.Sessions())
+}
+
+func TestPoolAcquireWaitsForRelease(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	pool, err := gosap.NewPool(server.Config(), 1)
+	require.NoError(t, err)
+
+	session, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = pool.Acquire(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	acquired := make(chan *gosap.Session)
+
+	go func() {
+		next, err := pool.Acquire(context.Background())
+		assert.NoError(t, err)
+
+		acquired <- next
+	}()
+
+	pool.Release(session)
+
+	next := <-acquired
+	assert.Equal(t, session.B1Session, next.B1Session)
+
+	pool.Release(next)
+	require.NoError(t, pool.Close())
+}
+
+func TestNewPoolFailsWithWrongCredentials(t *testing.T) {
+	t.Parallel()
+
+	server := gosaptest.NewServer()
+	defer server.Close()
+
+	cfg := server.Config()
+	cfg.Password = "wrong"
+
+	_, err := gosap.NewPool(cfg, 3)
+	require.Error(t, err)
+	assert.Zero(t, server.Sessions())
+}
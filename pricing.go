@@ -0,0 +1,207 @@
+package gosap
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PriceSource tells which SAP pricing rule produced an effective price.
+type PriceSource string
+
+const (
+	PriceSourcePriceList      PriceSource = "PriceList"
+	PriceSourceSpecialPrice   PriceSource = "SpecialPrice"
+	PriceSourcePeriodDiscount PriceSource = "PeriodDiscount"
+	PriceSourceVolumeDiscount PriceSource = "VolumeDiscount"
+)
+
+// PriceQuery identifies what is being priced.
+type PriceQuery struct {
+	CardCode string
+	ItemCode string
+	Quantity Decimal
+	Date     time.Time
+}
+
+// PricingData holds the SAP records an effective price is resolved from.
+type PricingData struct {
+	// PriceList is the price list of the business partner.
+	PriceList  int
+	ItemPrices []ItemPrice
+	// SpecialPrice is the special price of the item for the business partner, if any.
+	SpecialPrice *SpecialPrice
+	// PriceListDiscounts holds the period and volume discounts of PriceList, if any.
+	PriceListDiscounts *SpecialPrice
+}
+
+// EffectivePrice is the unit price a business partner pays for an item.
+type EffectivePrice struct {
+	Price     Decimal
+	Currency  string
+	Discount  Decimal
+	PriceList int
+	Source    PriceSource
+}
+
+// PriceListDiscountCardCode returns the CardCode SAP stores the period and volume discounts
+// of a price list under.
+func PriceListDiscountCardCode(priceList int) string {
+	return "*" + strconv.Itoa(priceList)
+}
+
+// ResolvePrice returns the effective unit price following SAP's precedence: special prices for
+// the business partner first, then the period and volume discounts of its price list and
+// finally the price list itself. Within a special price, a volume discount of a period that
+// covers the date wins over the period price, which wins over the base special price.
+func ResolvePrice(query PriceQuery, data PricingData) (*EffectivePrice, error) {
+	if err := query.Quantity.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quantity for item %s: %w", query.ItemCode, err)
+	}
+
+	for _, special := range []*SpecialPrice{data.SpecialPrice, data.PriceListDiscounts} {
+		if err := validateVolumes(special); err != nil {
+			return nil, err
+		}
+	}
+
+	if data.SpecialPrice != nil && data.SpecialPrice.Valid != BoNo {
+		return resolveSpecialPrice(query, *data.SpecialPrice, true), nil
+	}
+
+	if data.PriceListDiscounts != nil && data.PriceListDiscounts.Valid != BoNo {
+		if price := resolveSpecialPrice(query, *data.PriceListDiscounts, false); price != nil {
+			return price, nil
+		}
+	}
+
+	for _, itemPrice := range data.ItemPrices {
+		if itemPrice.PriceList == data.PriceList {
+			return &EffectivePrice{
+				Price:     itemPrice.Price,
+				Currency:  itemPrice.Currency,
+				PriceList: itemPrice.PriceList,
+				Source:    PriceSourcePriceList,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("item %s has no price in price list %d", query.ItemCode, data.PriceList)
+}
+
+// validateVolumes checks the volume discount quantities of special, which ResolvePrice
+// compares with the queried quantity.
+func validateVolumes(special *SpecialPrice) error {
+	if special == nil {
+		return nil
+	}
+
+	for _, period := range special.SpecialPriceDataAreas {
+		for _, volume := range period.SpecialPriceQuantityAreas {
+			if err := volume.Quantity.Validate(); err != nil {
+				return fmt.Errorf("invalid volume discount quantity for %s: %w", special.CardCode, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveSpecialPrice applies the periods and volume discounts of special. When withBase is
+// false, nil is returned if no period covers the date.
+func resolveSpecialPrice(query PriceQuery, special SpecialPrice, withBase bool) *EffectivePrice {
+	for _, period := range special.SpecialPriceDataAreas {
+		if !periodCovers(period, query.Date) {
+			continue
+		}
+
+		price := &EffectivePrice{
+			Price:     period.SpecialPrice,
+			Currency:  period.PriceCurrency,
+			Discount:  period.Discount,
+			PriceList: period.PriceListNo,
+			Source:    PriceSourcePeriodDiscount,
+		}
+
+		threshold := Zero
+		for _, volume := range period.SpecialPriceQuantityAreas {
+			if volume.Quantity.Cmp(query.Quantity) <= 0 && volume.Quantity.Cmp(threshold) >= 0 {
+				threshold = volume.Quantity
+				price.Price = volume.SpecialPrice
+				price.Discount = volume.Discount
+				price.Source = PriceSourceVolumeDiscount
+
+				if volume.PriceCurrency != "" {
+					price.Currency = volume.PriceCurrency
+				}
+			}
+		}
+
+		return price
+	}
+
+	if !withBase {
+		return nil
+	}
+
+	return &EffectivePrice{
+		Price:     special.Price,
+		Currency:  special.Currency,
+		Discount:  special.DiscountPercent,
+		PriceList: special.PriceListNum,
+		Source:    PriceSourceSpecialPrice,
+	}
+}
+
+// periodCovers reports whether date falls in the period. Missing bounds are open.
+func periodCovers(period SpecialPriceDataArea, date time.Time) bool {
+	day := DateOf(date)
+
+	if period.DateFrom != nil && !period.DateFrom.IsZero() && day.Time.Before(period.DateFrom.Time) {
+		return false
+	}
+
+	if period.DateTo != nil && !period.DateTo.IsZero() && day.Time.After(period.DateTo.Time) {
+		return false
+	}
+
+	return true
+}
+
+// ResolvePrice fetches the pricing records of the business partner and item and returns the
+// unit price SAP would apply for the quantity and date of query.
+func (s *Session) ResolvePrice(cfg Config, query PriceQuery) (_ *EffectivePrice, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	partner, err := retrieveDocument[BusinessPartner](s,
+		cfg.GetBusinessPartnerEndpoint(query.CardCode)+"?$select=CardCode,PriceListNum")
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch business partner %s due to %w", query.CardCode, err)
+	}
+
+	prices, err := s.GetItemPrices(cfg, query.ItemCode)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch prices of item %s due to %w", query.ItemCode, err)
+	}
+
+	listCardCode := PriceListDiscountCardCode(partner.PriceListNum)
+
+	specials, err := retrieveDocuments[SpecialPrice](s, cfg,
+		cfg.GetItemSpecialPricesEndpoint(query.ItemCode, query.CardCode, listCardCode))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch special prices of item %s due to %w", query.ItemCode, err)
+	}
+
+	data := PricingData{PriceList: partner.PriceListNum, ItemPrices: prices}
+	for i := range specials {
+		switch specials[i].CardCode {
+		case query.CardCode:
+			data.SpecialPrice = &specials[i]
+		case listCardCode:
+			data.PriceListDiscounts = &specials[i]
+		}
+	}
+
+	return ResolvePrice(query, data)
+}
@@ -0,0 +1,121 @@
+package gosap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePrice(t *testing.T) {
+	t.Parallel()
+
+	may := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	mayFirst, mayLast := gosap.NewDate(2024, time.May, 1), gosap.NewDate(2024, time.May, 31)
+	itemPrices := []gosap.ItemPrice{
+		{PriceList: 1, Price: "100", Currency: "EUR"},
+		{PriceList: 2, Price: "90", Currency: "EUR"},
+	}
+	special := &gosap.SpecialPrice{
+		ItemCode: "A00001", CardCode: "C20000", Price: "80", Currency: "EUR", DiscountPercent: "20", PriceListNum: 1,
+		SpecialPriceDataAreas: []gosap.SpecialPriceDataArea{
+			{
+				DateFrom: &mayFirst, DateTo: &mayLast,
+				SpecialPrice: "75", Discount: "25", PriceCurrency: "EUR", PriceListNo: 1,
+				SpecialPriceQuantityAreas: []gosap.SpecialPriceQuantityArea{
+					{Quantity: "10", SpecialPrice: "70", Discount: "30"},
+					{Quantity: "50", SpecialPrice: "60", Discount: "40"},
+				},
+			},
+		},
+	}
+	listDiscounts := &gosap.SpecialPrice{
+		ItemCode: "A00001", CardCode: gosap.PriceListDiscountCardCode(2),
+		SpecialPriceDataAreas: []gosap.SpecialPriceDataArea{
+			{DateFrom: &mayFirst, SpecialPrice: "85", Discount: "5.5", PriceCurrency: "EUR", PriceListNo: 2},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		query  gosap.PriceQuery
+		data   gosap.PricingData
+		price  gosap.Decimal
+		source gosap.PriceSource
+	}{
+		{
+			name:   "price_list",
+			query:  gosap.PriceQuery{Quantity: "1", Date: may},
+			data:   gosap.PricingData{PriceList: 2, ItemPrices: itemPrices},
+			price:  "90",
+			source: gosap.PriceSourcePriceList,
+		},
+		{
+			name:   "special_price_outside_period",
+			query:  gosap.PriceQuery{Quantity: "1", Date: may.AddDate(0, 1, 0)},
+			data:   gosap.PricingData{PriceList: 1, ItemPrices: itemPrices, SpecialPrice: special},
+			price:  "80",
+			source: gosap.PriceSourceSpecialPrice,
+		},
+		{
+			name:   "period_discount",
+			query:  gosap.PriceQuery{Quantity: "5", Date: may},
+			data:   gosap.PricingData{PriceList: 1, ItemPrices: itemPrices, SpecialPrice: special},
+			price:  "75",
+			source: gosap.PriceSourcePeriodDiscount,
+		},
+		{
+			name:   "volume_discount",
+			query:  gosap.PriceQuery{Quantity: "60", Date: may},
+			data:   gosap.PricingData{PriceList: 1, ItemPrices: itemPrices, SpecialPrice: special},
+			price:  "60",
+			source: gosap.PriceSourceVolumeDiscount,
+		},
+		{
+			name:   "price_list_period_discount",
+			query:  gosap.PriceQuery{Quantity: "1", Date: may},
+			data:   gosap.PricingData{PriceList: 2, ItemPrices: itemPrices, PriceListDiscounts: listDiscounts},
+			price:  "85",
+			source: gosap.PriceSourcePeriodDiscount,
+		},
+		{
+			name:   "price_list_discount_not_yet_valid",
+			query:  gosap.PriceQuery{Quantity: "1", Date: may.AddDate(0, -1, 0)},
+			data:   gosap.PricingData{PriceList: 2, ItemPrices: itemPrices, PriceListDiscounts: listDiscounts},
+			price:  "90",
+			source: gosap.PriceSourcePriceList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			price, err := gosap.ResolvePrice(tt.query, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.price, price.Price)
+			assert.Equal(t, tt.source, price.Source)
+		})
+	}
+
+	_, err := gosap.ResolvePrice(gosap.PriceQuery{ItemCode: "A00001"}, gosap.PricingData{PriceList: 3, ItemPrices: itemPrices})
+	assert.Error(t, err)
+}
+
+func TestResolvePriceInvalidQuantity(t *testing.T) {
+	t.Parallel()
+
+	data := gosap.PricingData{PriceList: 1, ItemPrices: []gosap.ItemPrice{{PriceList: 1, Price: "100"}}}
+
+	_, err := gosap.ResolvePrice(gosap.PriceQuery{ItemCode: "A00001", Quantity: "ten"}, data)
+	require.Error(t, err)
+
+	data.SpecialPrice = &gosap.SpecialPrice{CardCode: "C20000", SpecialPriceDataAreas: []gosap.SpecialPriceDataArea{
+		{SpecialPriceQuantityAreas: []gosap.SpecialPriceQuantityArea{{Quantity: "lots"}}},
+	}}
+
+	_, err = gosap.ResolvePrice(gosap.PriceQuery{ItemCode: "A00001", Quantity: "1"}, data)
+	require.Error(t, err)
+}
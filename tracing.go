@@ -0,0 +1,169 @@
+package gosap
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/octomiro/gosap"
+
+// Span attributes set by gosap besides the OpenTelemetry HTTP ones.
+const (
+	AttrOperation    = attribute.Key("gosap.operation")
+	AttrEntitySet    = attribute.Key("gosap.entity_set")
+	AttrDocEntry     = attribute.Key("gosap.doc_entry")
+	AttrPage         = attribute.Key("gosap.page")
+	AttrRetryAttempt = attribute.Key("gosap.retry_attempt")
+	AttrSAPErrorCode = attribute.Key("sap.error.code")
+)
+
+type operationSpanKey struct{}
+
+func (s *Session) tracer() trace.Tracer {
+	if provider := s.root().tracerProvider; provider != nil {
+		return provider.Tracer(tracerName)
+	}
+
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+// operationSpan ends the span of a gosap operation.
+type operationSpan struct {
+	span trace.Span
+}
+
+// finish records *err on the span, if any, and ends it. It is deferred by operations.
+func (o operationSpan) finish(err *error) {
+	if o.span == nil {
+		return
+	}
+
+	if *err != nil {
+		o.span.RecordError(*err)
+		o.span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	o.span.End()
+}
+
+// startOperation starts the span of the exported operation calling it, for operations sending
+// several requests such as page walks. The returned session sends them as children of the span.
+// Operations nested in another one don't get their own span.
+func (s *Session) startOperation() (*Session, operationSpan) {
+	ctx := s.context()
+	if ctx.Value(operationSpanKey{}) != nil {
+		return s, operationSpan{}
+	}
+
+	op := Operation(ctx)
+	if op == "" {
+		op = callerOperation()
+	}
+
+	ctx, span := s.startOperationSpan(WithOperation(ctx, op), op)
+
+	return s.WithContext(ctx), operationSpan{span: span}
+}
+
+func (s *Session) startOperationSpan(ctx context.Context, op string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{AttrOperation.String(op)}
+	if attempt := RetryAttempt(ctx); attempt > 0 {
+		attrs = append(attrs, AttrRetryAttempt.Int(attempt))
+	}
+
+	ctx, span := s.tracer().Start(ctx, "gosap."+op, trace.WithAttributes(attrs...))
+
+	return context.WithValue(ctx, operationSpanKey{}, true), span
+}
+
+// requestSpan traces one request sent by Do, and the operation around it when the request is
+// the whole operation.
+type requestSpan struct {
+	operation trace.Span
+	span      trace.Span
+}
+
+// entityPath matches the entity set and key after the API version, wherever BaseURL mounts it.
+var entityPath = regexp.MustCompile(`/v\d+/(\w+)(?:\(([^)]*)\))?(?:/\w+)?$`)
+
+// startRequest starts the span of req, a child of the operation span. It returns req carrying
+// the span, with the trace context propagated in its headers.
+func (s *Session) startRequest(req *http.Request) (*http.Request, requestSpan) {
+	var rs requestSpan
+
+	ctx := req.Context()
+	op := Operation(ctx)
+
+	if ctx.Value(operationSpanKey{}) == nil && op != "" {
+		ctx, rs.operation = s.startOperationSpan(ctx, op)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLPath(req.URL.Path),
+		semconv.ServerAddress(req.URL.Hostname()),
+		AttrOperation.String(op),
+	}
+
+	name := req.Method
+
+	if m := entityPath.FindStringSubmatch(req.URL.Path); m != nil {
+		name += " " + m[1]
+		attrs = append(attrs, AttrEntitySet.String(m[1]))
+
+		if docEntry, err := strconv.Atoi(m[2]); err == nil {
+			attrs = append(attrs, AttrDocEntry.Int(docEntry))
+		}
+	}
+
+	if page := PageOf(req); page > 0 {
+		attrs = append(attrs, AttrPage.Int(page))
+	}
+
+	if attempt := RetryAttempt(ctx); attempt > 0 {
+		attrs = append(attrs, AttrRetryAttempt.Int(attempt))
+	}
+
+	ctx, rs.span = s.tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, rs
+}
+
+// end records the outcome of the request and ends its spans.
+func (rs requestSpan) end(resp *http.Response, content []byte, err error) {
+	if resp != nil {
+		rs.span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+
+	if err != nil {
+		if code, _ := parseServiceLayerError(content); code != "" {
+			rs.span.SetAttributes(AttrSAPErrorCode.String(code))
+		}
+	}
+
+	for _, span := range []trace.Span{rs.span, rs.operation} {
+		if span == nil {
+			continue
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+}
@@ -0,0 +1,104 @@
+package gosap_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func TestTracingSpans(t *testing.T) {
+	t.Parallel()
+
+	conflicts := 1
+
+	cfg := serviceLayerStub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/b1s/v1/Login":
+			http.SetCookie(w, &http.Cookie{Name: "B1SESSION", Value: "session"})
+		case r.URL.Path == "/b1s/v1/BinLocations" && r.URL.Query().Get("$skip") == "":
+			_, _ = w.Write([]byte(`{"value":[{"AbsEntry":1}],"odata.nextLink":"/b1s/v1/BinLocations?$skip=1"}`))
+		case r.URL.Path == "/b1s/v1/BinLocations":
+			_, _ = w.Write([]byte(`{"value":[{"AbsEntry":2}]}`))
+		case r.Method == http.MethodPatch && conflicts > 0:
+			conflicts--
+			w.WriteHeader(http.StatusPreconditionFailed)
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":-2028,"message":{"lang":"en-us","value":"No matching records found"}}}`))
+		}
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	cfg.TracerProvider = provider
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "pipeline")
+
+	session, err := gosap.AuthenticateContext(ctx, cfg)
+	require.NoError(t, err)
+
+	locations, err := session.WithContext(ctx).GetBinLocations(cfg)
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+
+	_, err = session.WithContext(ctx).GetPurchaseOrder(cfg, "12")
+	require.Error(t, err)
+
+	err = gosap.RetryOnConflictContext(ctx, 3, func(ctx context.Context) error {
+		return session.WithContext(ctx).PatchBinLocation(cfg, 1, gosap.BinLocationUpdate{})
+	})
+	require.NoError(t, err)
+
+	parent.End()
+
+	spans := map[string][]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+
+	require.Len(t, spans["gosap.Authenticate"], 1)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans["gosap.Authenticate"][0].Parent().SpanID())
+
+	require.Len(t, spans["gosap.GetBinLocations"], 1)
+	walk := spans["gosap.GetBinLocations"][0]
+	assert.Equal(t, parent.SpanContext().SpanID(), walk.Parent().SpanID())
+
+	require.Len(t, spans["GET BinLocations"], 2)
+	for i, page := range spans["GET BinLocations"] {
+		assert.Equal(t, walk.SpanContext().SpanID(), page.Parent().SpanID())
+		assert.Equal(t, int64(i+1), spanAttributes(page)["gosap.page"].AsInt64())
+		assert.Equal(t, "BinLocations", spanAttributes(page)["gosap.entity_set"].AsString())
+	}
+
+	require.Len(t, spans["GET PurchaseOrders"], 1)
+	get := spans["GET PurchaseOrders"][0]
+	assert.Equal(t, int64(12), spanAttributes(get)["gosap.doc_entry"].AsInt64())
+	assert.Equal(t, "-2028", spanAttributes(get)["sap.error.code"].AsString())
+	assert.Equal(t, codes.Error, get.Status().Code)
+	assert.Equal(t, codes.Error, spans["gosap.GetPurchaseOrder"][0].Status().Code)
+
+	require.Len(t, spans["PATCH BinLocations"], 2)
+	for i, patch := range spans["PATCH BinLocations"] {
+		assert.Equal(t, int64(i+1), spanAttributes(patch)["gosap.retry_attempt"].AsInt64())
+		assert.Equal(t, "PatchBinLocation", spanAttributes(patch)["gosap.operation"].AsString())
+	}
+}
@@ -1,65 +1,255 @@
 package gosap
 
+import "encoding/json"
+
 type Item struct {
-	ItemCode          string
-	ItemName          string
-	PurchaseUnitWidth *float64
+	ItemCode              string        `json:",omitempty"`
+	ItemName              string        `json:",omitempty"`
+	ForeignName           string        `json:",omitempty"`
+	ItemsGroupCode        int           `json:",omitempty"`
+	ItemType              BoItemTypes   `json:",omitempty"`
+	UoMGroupEntry         int           `json:",omitempty"`
+	InventoryUOM          string        `json:",omitempty"`
+	SalesUnit             string        `json:",omitempty"`
+	PurchaseUnit          string        `json:",omitempty"`
+	InventoryItem         BoYesNoEnum   `json:",omitempty"`
+	SalesItem             BoYesNoEnum   `json:",omitempty"`
+	PurchaseItem          BoYesNoEnum   `json:",omitempty"`
+	ManageBatchNumbers    BoYesNoEnum   `json:",omitempty"`
+	ManageSerialNumbers   BoYesNoEnum   `json:",omitempty"`
+	DefaultWarehouse      string        `json:",omitempty"`
+	Mainsupplier          string        `json:",omitempty"`
+	BarCode               string        `json:",omitempty"`
+	ItemBarCodeCollection []ItemBarCode `json:",omitempty"`
+	ItemPrices            []ItemPrice   `json:",omitempty"`
+	QuantityOnStock       Decimal       `json:",omitempty"`
+	SalesUnitLength       Decimal       `json:",omitempty"`
+	SalesUnitWidth        Decimal       `json:",omitempty"`
+	SalesUnitHeight       Decimal       `json:",omitempty"`
+	SalesUnitVolume       Decimal       `json:",omitempty"`
+	SalesUnitWeight       Decimal       `json:",omitempty"`
+	PurchaseUnitLength    Decimal       `json:",omitempty"`
+	PurchaseUnitWidth     *Decimal      `json:",omitempty"` // nullable: a selected width of 0 differs from an unselected one
+	PurchaseUnitHeight    Decimal       `json:",omitempty"`
+	PurchaseUnitVolume    Decimal       `json:",omitempty"`
+	PurchaseUnitWeight    Decimal       `json:",omitempty"`
+	Valid                 BoYesNoEnum   `json:",omitempty"`
+	Frozen                BoYesNoEnum   `json:",omitempty"`
+	UserFields            UserFields    `json:"-"`
+}
+
+// ItemBarCode is one of the additional barcodes of an item, optionally bound to a unit of
+// measure.
+type ItemBarCode struct {
+	AbsEntry int    `json:",omitempty"`
+	UoMEntry int    `json:",omitempty"`
+	Barcode  string `json:",omitempty"`
+	FreeText string `json:",omitempty"`
+}
+
+// ItemPrice is the price of an item in one price list.
+type ItemPrice struct {
+	PriceList     int     `json:",omitempty"`
+	Price         Decimal `json:",omitempty"`
+	Currency      string  `json:",omitempty"`
+	BasePriceList int     `json:",omitempty"`
+	Factor        Decimal `json:",omitempty"`
 }
 
+// DocumentLine holds the fields SAP shares between the lines of every marketing document.
+// Line types of specific documents embed it and add their own fields.
 type DocumentLine struct {
-	LineNum          int
-	ItemCode         string  `json:",omitempty"`
-	ItemDescription  string  `json:",omitempty"`
-	Quantity         float64 `json:",omitempty"`
-	SelectedQuantity float64 `json:"U_SelectedQuantity,omitempty"`
-	ShipDate         string  `json:",omitempty"`
-	Price            float64 `json:",omitempty"`
+	LineNum               int
+	ItemCode              string     `json:",omitempty"`
+	ItemDescription       string     `json:",omitempty"`
+	Quantity              Decimal    `json:",omitempty"`
+	ShipDate              *Date      `json:",omitempty"`
+	Price                 Decimal    `json:",omitempty"`
+	Currency              string     `json:",omitempty"`
+	DiscountPercent       Decimal    `json:",omitempty"`
+	LineTotal             Decimal    `json:",omitempty"`
+	TaxCode               string     `json:",omitempty"`
+	WarehouseCode         string     `json:",omitempty"`
+	UoMEntry              int        `json:",omitempty"`
+	UoMCode               string     `json:",omitempty"`
+	UnitsOfMeasurment     Decimal    `json:",omitempty"`
+	AccountCode           string     `json:",omitempty"`
+	CostingCode           string     `json:",omitempty"`
+	CostingCode2          string     `json:",omitempty"`
+	CostingCode3          string     `json:",omitempty"`
+	CostingCode4          string     `json:",omitempty"`
+	CostingCode5          string     `json:",omitempty"`
+	ProjectCode           string     `json:",omitempty"`
+	RemainingOpenQuantity Decimal    `json:",omitempty"`
+	OpenAmount            Decimal    `json:",omitempty"`
+	LineStatus            BoStatus   `json:",omitempty"`
+	BaseType              int        `json:",omitempty"`
+	BaseEntry             int        `json:",omitempty"`
+	BaseLine              *int       `json:",omitempty"`
+	TargetType            int        `json:",omitempty"`
+	TargetEntry           int        `json:",omitempty"`
+	UserFields            UserFields `json:"-"`
 }
 
+type DeliveryNoteLine struct {
+	DocumentLine
+	SelectedQuantity Decimal `json:"U_SelectedQuantity,omitempty"`
+}
+
+type PurchaseOrderLine struct {
+	DocumentLine
+	SelectedQuantity Decimal `json:"U_SelectedQuantity,omitempty"`
+	RequiredDate     *Date   `json:",omitempty"`
+	SupplierCatNum   string  `json:",omitempty"`
+}
+
+type PurchaseDeliveryNoteLine struct {
+	DocumentLine
+	SupplierCatNum string `json:",omitempty"`
+}
+
+// DocumentHeader holds the header fields SAP shares between every marketing document.
+type DocumentHeader struct {
+	DocNum           int               `json:"DocNum,omitempty"`
+	DocEntry         int               `json:"DocEntry,omitempty"`
+	DocType          BoDocumentTypes   `json:"DocType,omitempty"`
+	CardCode         string            `json:",omitempty"`
+	CardName         string            `json:",omitempty"`
+	Status           BoStatus          `json:"DocumentStatus,omitempty"`
+	Cancelled        BoYesNoEnum       `json:",omitempty"`
+	Series           int               `json:",omitempty"`
+	DocDate          *Date             `json:",omitempty"`
+	DocDueDate       *Date             `json:",omitempty"`
+	TaxDate          *Date             `json:",omitempty"`
+	DocTotal         Decimal           `json:",omitempty"`
+	VatSum           Decimal           `json:",omitempty"`
+	DocCurrency      string            `json:",omitempty"`
+	DocRate          Decimal           `json:",omitempty"`
+	Comments         string            `json:",omitempty"`
+	NumAtCard        string            `json:",omitempty"`
+	SalesPersonCode  int               `json:",omitempty"`
+	ShipToCode       string            `json:",omitempty"`
+	PayToCode        string            `json:",omitempty"`
+	Address          string            `json:",omitempty"`
+	Address2         string            `json:",omitempty"`
+	AddressExtension *AddressExtension `json:",omitempty"`
+	UserFields       UserFields        `json:"-"`
+}
+
+// Document is a marketing document of any kind, carrying only the fields shared by all of
+// them.
 type Document struct {
-	DocNum        int    `json:"DocNum,omitempty"`
-	DocEntry      int    `json:"DocEntry,omitempty"`
-	DocType       string `json:"DocType,omitempty"`
-	CardCode      string `json:",omitempty"`
-	Status        string `json:"DocumentStatus,omitempty"`
-	PlateNum      string `json:"U_PlateNum,omitempty"`
+	DocumentHeader
 	DocumentLines []DocumentLine
 }
 
+type DeliveryNote struct {
+	DocumentHeader
+	PlateNum      string `json:"U_PlateNum,omitempty"`
+	DocumentLines []DeliveryNoteLine
+}
+
+type PurchaseOrder struct {
+	DocumentHeader
+	PlateNum      string `json:"U_PlateNum,omitempty"`
+	DocumentLines []PurchaseOrderLine
+}
+
 type PurchaseDeliveryNote struct {
-	DocNum        int    `json:"DocNum,omitempty"`
-	DocEntry      int    `json:"DocEntry,omitempty"`
-	DocType       string `json:"DocType,omitempty"`
-	CardCode      string `json:",omitempty"`
-	Status        string `json:"DocumentStatus,omitempty"`
+	DocumentHeader
 	DocumentLines []PurchaseDeliveryNoteLine
 }
 
-type PurchaseDeliveryNoteLine struct {
-	LineNum         int     `json:",omitempty"`
-	ItemCode        string  `json:",omitempty"`
-	ItemDescription string  `json:",omitempty"`
-	Quantity        float64 `json:",omitempty"`
-	ShipDate        string  `json:",omitempty"`
-	Price           float64 `json:",omitempty"`
-	BaseType        int     `json:",omitempty"`
-	BaseEntry       int     `json:",omitempty"`
-	BaseLine        int
+// AddressExtension holds the structured ship-to and bill-to addresses of a document. Address
+// and Address2 on the document are SAP's formatted rendering of them.
+type AddressExtension struct {
+	ShipToStreet   string `json:",omitempty"`
+	ShipToStreetNo string `json:",omitempty"`
+	ShipToBlock    string `json:",omitempty"`
+	ShipToBuilding string `json:",omitempty"`
+	ShipToCity     string `json:",omitempty"`
+	ShipToZipCode  string `json:",omitempty"`
+	ShipToCounty   string `json:",omitempty"`
+	ShipToState    string `json:",omitempty"`
+	ShipToCountry  string `json:",omitempty"`
+	ShipToAddress2 string `json:",omitempty"`
+	ShipToAddress3 string `json:",omitempty"`
+	BillToStreet   string `json:",omitempty"`
+	BillToStreetNo string `json:",omitempty"`
+	BillToBlock    string `json:",omitempty"`
+	BillToBuilding string `json:",omitempty"`
+	BillToCity     string `json:",omitempty"`
+	BillToZipCode  string `json:",omitempty"`
+	BillToCounty   string `json:",omitempty"`
+	BillToState    string `json:",omitempty"`
+	BillToCountry  string `json:",omitempty"`
+	BillToAddress2 string `json:",omitempty"`
+	BillToAddress3 string `json:",omitempty"`
 }
 
-type (
-	DeliveryNote      = Document
-	DeliveryNoteLine  = DocumentLine
-	PurchaseOrder     = Document
-	PurchaseOrderLine = DocumentLine
+// IsOpen reports whether the document is open. It is promoted to every document type.
+func (h *DocumentHeader) IsOpen() bool {
+	return h.Status == BoStatusOpen
+}
+
+// IsClosed reports whether the document is closed, which cancelled documents are as well.
+func (h *DocumentHeader) IsClosed() bool {
+	return h.Status == BoStatusClose
+}
+
+// IsCancelled reports whether the document was cancelled.
+func (h *DocumentHeader) IsCancelled() bool {
+	return h.Cancelled == BoYes
+}
+
+// DocumentObjectCode identifies the document type of a draft.
+type DocumentObjectCode string
+
+const (
+	ObjectQuotations            DocumentObjectCode = "oQuotations"
+	ObjectOrders                DocumentObjectCode = "oOrders"
+	ObjectDeliveryNotes         DocumentObjectCode = "oDeliveryNotes"
+	ObjectReturns               DocumentObjectCode = "oReturns"
+	ObjectInvoices              DocumentObjectCode = "oInvoices"
+	ObjectPurchaseRequest       DocumentObjectCode = "oPurchaseRequest"
+	ObjectPurchaseQuotations    DocumentObjectCode = "oPurchaseQuotations"
+	ObjectPurchaseOrders        DocumentObjectCode = "oPurchaseOrders"
+	ObjectPurchaseDeliveryNotes DocumentObjectCode = "oPurchaseDeliveryNotes"
+	ObjectPurchaseReturns       DocumentObjectCode = "oPurchaseReturns"
+	ObjectPurchaseInvoices      DocumentObjectCode = "oPurchaseInvoices"
 )
 
-func (dn *DeliveryNote) IsOpen() bool {
-	return dn.Status == "bost_Open"
+// Draft is a document saved in the Drafts table, e.g. while it waits for approval.
+type Draft struct {
+	DocumentHeader
+	DocObjectCode DocumentObjectCode `json:",omitempty"`
+	DocumentLines []DocumentLine
 }
 
-func (dn *DeliveryNote) IsClosed() bool {
-	return dn.Status == "bost_Close"
+// ApprovalRequest is raised when a document matches an approval template.
+type ApprovalRequest struct {
+	Code                     int                         `json:",omitempty"`
+	ApprovalTemplatesID      int                         `json:",omitempty"`
+	ObjectType               string                      `json:",omitempty"`
+	IsDraft                  BoYesNoEnum                 `json:",omitempty"`
+	ObjectEntry              int                         `json:",omitempty"`
+	DraftEntry               int                         `json:",omitempty"`
+	DraftType                string                      `json:",omitempty"`
+	Status                   BoApprovalRequestStatusEnum `json:",omitempty"`
+	Remarks                  string                      `json:",omitempty"`
+	CurrentStage             int                         `json:",omitempty"`
+	OriginatorID             int                         `json:",omitempty"`
+	CreationDate             *Date                       `json:",omitempty"`
+	CreationTime             *TimeOfDay                  `json:",omitempty"`
+	ApprovalRequestDecisions []ApprovalRequestDecision   `json:",omitempty"`
+}
+
+// ApprovalRequestDecision is the decision of one approver on an approval request.
+type ApprovalRequestDecision struct {
+	ApproverUserName string                        `json:",omitempty"`
+	ApproverPassword string                        `json:",omitempty"`
+	Status           BoApprovalRequestDecisionEnum `json:",omitempty"`
+	Remarks          string                        `json:",omitempty"`
 }
 
 type DeliveryNotes struct {
@@ -75,13 +265,138 @@ type Items struct {
 }
 
 type BusinessPartner struct {
-	CardCode string
-	CardName string
+	CardCode            string            `json:",omitempty"`
+	CardName            string            `json:",omitempty"`
+	CardForeignName     string            `json:",omitempty"`
+	CardType            BoCardTypes       `json:",omitempty"`
+	GroupCode           int               `json:",omitempty"`
+	Currency            string            `json:",omitempty"`
+	PayTermsGrpCode     int               `json:",omitempty"`
+	PriceListNum        int               `json:",omitempty"`
+	SalesPersonCode     int               `json:",omitempty"`
+	CreditLimit         Decimal           `json:",omitempty"`
+	DiscountPercent     Decimal           `json:",omitempty"`
+	FederalTaxID        string            `json:",omitempty"`
+	AdditionalID        string            `json:",omitempty"`
+	UnifiedFederalTaxID string            `json:",omitempty"`
+	VatGroup            string            `json:",omitempty"`
+	VatLiable           BoVatStatus       `json:",omitempty"`
+	Phone1              string            `json:",omitempty"`
+	Phone2              string            `json:",omitempty"`
+	Cellular            string            `json:",omitempty"`
+	EmailAddress        string            `json:",omitempty"`
+	Website             string            `json:",omitempty"`
+	ContactPerson       string            `json:",omitempty"`
+	Notes               string            `json:",omitempty"`
+	ShipToDefault       string            `json:",omitempty"`
+	BilltoDefault       string            `json:",omitempty"`
+	Valid               BoYesNoEnum       `json:",omitempty"`
+	Frozen              BoYesNoEnum       `json:",omitempty"`
+	BPAddresses         []BPAddress       `json:",omitempty"`
+	ContactEmployees    []ContactEmployee `json:",omitempty"`
+	UserFields          UserFields        `json:"-"`
+}
+
+// BPAddress is a bill-to or ship-to address of a business partner. SAP identifies it by
+// AddressName and AddressType.
+type BPAddress struct {
+	AddressName       string        `json:",omitempty"`
+	AddressType       BoAddressType `json:",omitempty"`
+	Street            string        `json:",omitempty"`
+	StreetNo          string        `json:",omitempty"`
+	Block             string        `json:",omitempty"`
+	BuildingFloorRoom string        `json:",omitempty"`
+	ZipCode           string        `json:",omitempty"`
+	City              string        `json:",omitempty"`
+	County            string        `json:",omitempty"`
+	State             string        `json:",omitempty"`
+	Country           string        `json:",omitempty"`
+	FederalTaxID      string        `json:",omitempty"`
+	TaxCode           string        `json:",omitempty"`
+	BPCode            string        `json:",omitempty"`
+	RowNum            int           `json:",omitempty"`
+	UserFields        UserFields    `json:"-"`
+}
+
+// ContactEmployee is a contact person of a business partner.
+type ContactEmployee struct {
+	InternalCode int         `json:",omitempty"`
+	CardCode     string      `json:",omitempty"`
+	Name         string      `json:",omitempty"`
+	FirstName    string      `json:",omitempty"`
+	MiddleName   string      `json:",omitempty"`
+	LastName     string      `json:",omitempty"`
+	Title        string      `json:",omitempty"`
+	Position     string      `json:",omitempty"`
+	Address      string      `json:",omitempty"`
+	Phone1       string      `json:",omitempty"`
+	Phone2       string      `json:",omitempty"`
+	MobilePhone  string      `json:",omitempty"`
+	Fax          string      `json:",omitempty"`
+	EMail        string      `json:"E_Mail,omitempty"` //nolint:tagliatelle
+	Remarks1     string      `json:",omitempty"`
+	Active       BoYesNoEnum `json:",omitempty"`
+	UserFields   UserFields  `json:"-"`
+}
+
+// PriceList is a price list header. Item prices of the list are stored on the items.
+type PriceList struct {
+	PriceListNo          int         `json:",omitempty"`
+	PriceListName        string      `json:",omitempty"`
+	BasePriceList        int         `json:",omitempty"`
+	Factor               Decimal     `json:",omitempty"`
+	RoundingMethod       string      `json:",omitempty"`
+	GroupNum             string      `json:",omitempty"`
+	IsGrossPrice         BoYesNoEnum `json:",omitempty"`
+	Active               BoYesNoEnum `json:",omitempty"`
+	ValidFrom            *Date       `json:",omitempty"`
+	ValidTo              *Date       `json:",omitempty"`
+	DefaultPrimeCurrency string      `json:",omitempty"`
+	UserFields           UserFields  `json:"-"`
+}
+
+// SpecialPrice is a special price of an item for a business partner. A CardCode of "*"
+// followed by a price list number holds the period and volume discounts of that price list.
+type SpecialPrice struct {
+	ItemCode              string                 `json:",omitempty"`
+	CardCode              string                 `json:",omitempty"`
+	Price                 Decimal                `json:",omitempty"`
+	Currency              string                 `json:",omitempty"`
+	DiscountPercent       Decimal                `json:",omitempty"`
+	PriceListNum          int                    `json:",omitempty"`
+	AutoUpdate            BoYesNoEnum            `json:",omitempty"`
+	Valid                 BoYesNoEnum            `json:",omitempty"`
+	SpecialPriceDataAreas []SpecialPriceDataArea `json:",omitempty"`
+	UserFields            UserFields             `json:"-"`
+}
+
+// SpecialPriceDataArea is the price of a special price during a period.
+type SpecialPriceDataArea struct {
+	RowNumber                 int                        `json:",omitempty"`
+	DateFrom                  *Date                      `json:",omitempty"`
+	DateTo                    *Date                      `json:"Dateto,omitempty"` //nolint:tagliatelle
+	Discount                  Decimal                    `json:",omitempty"`
+	SpecialPrice              Decimal                    `json:",omitempty"`
+	PriceCurrency             string                     `json:",omitempty"`
+	PriceListNo               int                        `json:",omitempty"`
+	AutoUpdate                BoYesNoEnum                `json:",omitempty"`
+	SpecialPriceQuantityAreas []SpecialPriceQuantityArea `json:",omitempty"`
+}
+
+// SpecialPriceQuantityArea is the volume discount applying from Quantity within a period.
+type SpecialPriceQuantityArea struct {
+	RowNumber     int     `json:",omitempty"`
+	Quantity      Decimal `json:",omitempty"`
+	Discount      Decimal `json:",omitempty"`
+	SpecialPrice  Decimal `json:",omitempty"`
+	PriceCurrency string  `json:",omitempty"`
+	UoMEntry      int     `json:",omitempty"`
 }
 
 type (
 	Supplier = BusinessPartner
 	Client   = BusinessPartner
+	Lead     = BusinessPartner
 )
 
 type BusinessPartners struct {
@@ -93,6 +408,7 @@ type BusinessPartners struct {
 type (
 	Suppliers = BusinessPartners
 	Clients   = BusinessPartners
+	Leads     = BusinessPartners
 )
 
 type PurchaseOrders struct {
@@ -107,37 +423,123 @@ type PurchaseDeliveryNotes struct {
 	NextLink *string                `json:"odata.nextLink"` //nolint:tagliatelle
 }
 
+// collection is a single page of any Service Layer entity set.
+type collection[T any] struct {
+	Value    []T     `json:"value"`
+	NextLink *string `json:"odata.nextLink"` //nolint:tagliatelle
+}
+
 type InventoryCountingLine struct {
-	ItemCode        string  `json:"ItemCode,omitempty"`
-	WarehouseCode   string  `json:"WarehouseCode,omitempty"`
-	CountedQuantity float64 `json:"CountedQuantity,omitempty"`
-	LineNum         int     `json:"LineNumber,omitempty"`
-	ItemDescription string  `json:"ItemDescription,omitempty"`
-	BinEntry        int     `json:"BinEntry,omitempty"`
+	ItemCode        string     `json:"ItemCode,omitempty"`
+	WarehouseCode   string     `json:"WarehouseCode,omitempty"`
+	CountedQuantity Decimal    `json:"CountedQuantity,omitempty"`
+	LineNum         int        `json:"LineNumber,omitempty"`
+	ItemDescription string     `json:"ItemDescription,omitempty"`
+	BinEntry        int        `json:"BinEntry,omitempty"`
+	UserFields      UserFields `json:"-"`
 }
 
 type InventoryCounting struct {
-	DocumentEntry          int                     `json:"DocumentEntry,omitempty"`
-	DocumentNumber         int                     `json:"DocumentNumber,omitempty"`
-	Series                 int                     `json:"Series,omitempty"`
-	CountingType           string                  `json:"CountingType,omitempty"`
-	DocumentStatus         string                  `json:"DocumentStatus,omitempty"`
-	InventoryCountingLines []InventoryCountingLine `json:"InventoryCountingLines,omitempty"`
+	DocumentEntry          int                        `json:"DocumentEntry,omitempty"`
+	DocumentNumber         int                        `json:"DocumentNumber,omitempty"`
+	Series                 int                        `json:"Series,omitempty"`
+	CountingType           CountingTypeEnum           `json:"CountingType,omitempty"`
+	CountDate              *Date                      `json:"CountDate,omitempty"`
+	CountTime              *TimeOfDay                 `json:"CountTime,omitempty"`
+	DocumentStatus         CountingDocumentStatusEnum `json:"DocumentStatus,omitempty"`
+	InventoryCountingLines []InventoryCountingLine    `json:"InventoryCountingLines,omitempty"`
+	UserFields             UserFields                 `json:"-"`
+}
+
+func (c *InventoryCounting) IsOpen() bool {
+	return c.DocumentStatus == CountingStatusOpen
+}
+
+func (c *InventoryCounting) IsClosed() bool {
+	return c.DocumentStatus == CountingStatusClosed
 }
 
 type InventoryCountingResponse struct {
-	ODataMetadata string              `json:"@odata.metadata"`
+	ODataMetadata string              `json:"odata.metadata"` //nolint:tagliatelle
 	Value         []InventoryCounting `json:"value"`
-	NextLink      *string             `json:"odata.nextLink"`
+	NextLink      *string             `json:"odata.nextLink"` //nolint:tagliatelle
 }
 
 type BinLocation struct {
-	AbsEntry    int     `json:"AbsEntry,omitempty"`
-	Warehouse   string  `json:"Warehouse,omitempty"`
-	BinCode     string  `json:"BinCode,omitempty"`
-	Description *string `json:"Description,omitempty"`
-	MinimumQty  float64 `json:"MinimumQty,omitempty"`
-	MaximumQty  float64 `json:"MaximumQty,omitempty"`
+	AbsEntry                int         `json:"AbsEntry,omitempty"`
+	Warehouse               string      `json:"Warehouse,omitempty"`
+	BinCode                 string      `json:"BinCode,omitempty"`
+	Inactive                BoYesNoEnum `json:"Inactive,omitempty"`
+	Description             *string     `json:"Description,omitempty"`
+	AlternativeSortCode     string      `json:"AlternativeSortCode,omitempty"`
+	BarCode                 string      `json:"BarCode,omitempty"`
+	Sublevel1               string      `json:"Sublevel1,omitempty"`
+	Sublevel2               string      `json:"Sublevel2,omitempty"`
+	Sublevel3               string      `json:"Sublevel3,omitempty"`
+	Sublevel4               string      `json:"Sublevel4,omitempty"`
+	Attribute1              string      `json:"Attribute1,omitempty"`
+	Attribute2              string      `json:"Attribute2,omitempty"`
+	Attribute3              string      `json:"Attribute3,omitempty"`
+	Attribute4              string      `json:"Attribute4,omitempty"`
+	Attribute5              string      `json:"Attribute5,omitempty"`
+	Attribute6              string      `json:"Attribute6,omitempty"`
+	Attribute7              string      `json:"Attribute7,omitempty"`
+	Attribute8              string      `json:"Attribute8,omitempty"`
+	Attribute9              string      `json:"Attribute9,omitempty"`
+	Attribute10             string      `json:"Attribute10,omitempty"`
+	MinimumQty              Decimal     `json:"MinimumQty,omitempty"`
+	MaximumQty              Decimal     `json:"MaximumQty,omitempty"`
+	MaximumWeight           Decimal     `json:"MaximumWeight,omitempty"`
+	ReceivingBinLocation    BoYesNoEnum `json:"ReceivingBinLocation,omitempty"`
+	ExcludeAutoAllocOnIssue BoYesNoEnum `json:"ExcludeAutoAllocOnIssue,omitempty"`
+	UserFields              UserFields  `json:"-"`
+}
+
+// BinLocationUpdate holds the bin location fields to change. Nil fields are left untouched.
+type BinLocationUpdate struct {
+	Inactive                *BoYesNoEnum `json:"Inactive,omitempty"`
+	Description             *string      `json:"Description,omitempty"`
+	AlternativeSortCode     *string      `json:"AlternativeSortCode,omitempty"`
+	BarCode                 *string      `json:"BarCode,omitempty"`
+	Attribute1              *string      `json:"Attribute1,omitempty"`
+	Attribute2              *string      `json:"Attribute2,omitempty"`
+	Attribute3              *string      `json:"Attribute3,omitempty"`
+	Attribute4              *string      `json:"Attribute4,omitempty"`
+	Attribute5              *string      `json:"Attribute5,omitempty"`
+	Attribute6              *string      `json:"Attribute6,omitempty"`
+	Attribute7              *string      `json:"Attribute7,omitempty"`
+	Attribute8              *string      `json:"Attribute8,omitempty"`
+	Attribute9              *string      `json:"Attribute9,omitempty"`
+	Attribute10             *string      `json:"Attribute10,omitempty"`
+	MinimumQty              *Decimal     `json:"MinimumQty,omitempty"`
+	MaximumQty              *Decimal     `json:"MaximumQty,omitempty"`
+	MaximumWeight           *Decimal     `json:"MaximumWeight,omitempty"`
+	ReceivingBinLocation    *BoYesNoEnum `json:"ReceivingBinLocation,omitempty"`
+	ExcludeAutoAllocOnIssue *BoYesNoEnum `json:"ExcludeAutoAllocOnIssue,omitempty"`
+}
+
+// BinLocationField is one of the segments (warehouse, sublevels, attributes) bin codes are
+// built from.
+type BinLocationField struct {
+	AbsEntry    int         `json:"AbsEntry,omitempty"`
+	FieldName   string      `json:"FieldName,omitempty"`
+	FieldType   string      `json:"FieldType,omitempty"`
+	FieldNumber int         `json:"FieldNumber,omitempty"`
+	Activated   BoYesNoEnum `json:"Activated,omitempty"`
+}
+
+// BinLocationFieldUpdate holds the bin location field properties to change. Nil fields are
+// left untouched.
+type BinLocationFieldUpdate struct {
+	FieldName *string      `json:"FieldName,omitempty"`
+	Activated *BoYesNoEnum `json:"Activated,omitempty"`
+}
+
+// BinLocationAttribute is a code allowed for one of the ten bin location attributes.
+type BinLocationAttribute struct {
+	AbsEntry  int    `json:"AbsEntry,omitempty"`
+	Attribute int    `json:"Attribute,omitempty"`
+	Code      string `json:"Code,omitempty"`
 }
 
 type BinLocationsResponse struct {
@@ -145,3 +547,80 @@ type BinLocationsResponse struct {
 	Value    []BinLocation `json:"value,omitempty"`
 	NextLink *string       `json:"odata.nextLink,omitempty"` //nolint:tagliatelle
 }
+
+// UserTable is the definition of a user-defined table (UserTablesMD). TableName is given
+// without the leading @.
+type UserTable struct {
+	TableName        string         `json:",omitempty"`
+	TableDescription string         `json:",omitempty"`
+	TableType        BoUTBTableType `json:",omitempty"`
+	Archivable       BoYesNoEnum    `json:",omitempty"`
+}
+
+// UserField is the definition of a user-defined field (UserFieldsMD). Name is given without
+// the U_ prefix and TableName is either a system table (OCRD, ORDR, ...) or @ followed by a
+// user table name.
+type UserField struct {
+	FieldID       *int             `json:",omitempty"`
+	TableName     string           `json:",omitempty"`
+	Name          string           `json:",omitempty"`
+	Description   string           `json:",omitempty"`
+	Type          BoFieldTypes     `json:",omitempty"`
+	SubType       BoFldSubTypes    `json:",omitempty"`
+	Size          int              `json:",omitempty"`
+	EditSize      int              `json:",omitempty"`
+	DefaultValue  string           `json:",omitempty"`
+	Mandatory     BoYesNoEnum      `json:",omitempty"`
+	LinkedTable   string           `json:",omitempty"`
+	LinkedUDO     string           `json:",omitempty"`
+	ValidValuesMD []UserFieldValue `json:",omitempty"`
+}
+
+// UserFieldValue is one of the values allowed for a user-defined field.
+type UserFieldValue struct {
+	Value       string `json:",omitempty"`
+	Description string `json:",omitempty"`
+}
+
+// UserObject is the registration of a user-defined object (UserObjectsMD) on top of user
+// tables.
+type UserObject struct {
+	Code                 string                `json:",omitempty"`
+	Name                 string                `json:",omitempty"`
+	TableName            string                `json:",omitempty"`
+	ObjectType           BoUDOObjType          `json:",omitempty"`
+	CanCancel            BoYesNoEnum           `json:",omitempty"`
+	CanClose             BoYesNoEnum           `json:",omitempty"`
+	CanDelete            BoYesNoEnum           `json:",omitempty"`
+	CanFind              BoYesNoEnum           `json:",omitempty"`
+	CanLog               BoYesNoEnum           `json:",omitempty"`
+	CanYearTransfer      BoYesNoEnum           `json:",omitempty"`
+	CanCreateDefaultForm BoYesNoEnum           `json:",omitempty"`
+	ManageSeries         BoYesNoEnum           `json:",omitempty"`
+	ChildTables          []UserObjectChild     `json:"UserObjectMD_ChildTables,omitempty"` //nolint:tagliatelle
+	FindColumns          []UserObjectFindField `json:"UserObjectMD_FindColumns,omitempty"` //nolint:tagliatelle
+}
+
+// UserObjectChild is a line table of a user-defined object.
+type UserObjectChild struct {
+	TableName  string `json:",omitempty"`
+	ObjectName string `json:",omitempty"`
+}
+
+// UserObjectFindField is a column shown in the find dialog of a user-defined object.
+type UserObjectFindField struct {
+	ColumnAlias       string `json:",omitempty"`
+	ColumnDescription string `json:",omitempty"`
+}
+
+// UserTableRow is a row of a user-defined table without object, read and written through
+// the U_<table> entity set. Its columns are in UserFields.
+type UserTableRow struct {
+	Code       string     `json:",omitempty"`
+	Name       string     `json:",omitempty"`
+	UserFields UserFields `json:"-"`
+}
+
+// UserObjectRecord is a record of a user-defined object. Properties, including child table
+// collections, are kept as raw JSON by name since their layout is defined in SAP.
+type UserObjectRecord map[string]json.RawMessage
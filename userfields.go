@@ -0,0 +1,553 @@
+package gosap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UserFieldPrefix starts the name of every user-defined field (UDF) in the Service Layer.
+const UserFieldPrefix = "U_"
+
+// UserFields holds the user-defined fields of an entity that gosap doesn't map to a struct
+// field, keyed by their full name (U_...). Values keep the exact JSON the Service Layer sent so
+// they are written back unchanged.
+//
+// Accessors accept names with or without the U_ prefix.
+type UserFields map[string]json.RawMessage
+
+func userFieldName(name string) string {
+	if strings.HasPrefix(name, UserFieldPrefix) {
+		return name
+	}
+
+	return UserFieldPrefix + name
+}
+
+// Has reports whether the field is present, even if it is null.
+func (u UserFields) Has(name string) bool {
+	_, ok := u[userFieldName(name)]
+
+	return ok
+}
+
+// IsNull reports whether the field is absent or null.
+func (u UserFields) IsNull(name string) bool {
+	raw, ok := u[userFieldName(name)]
+
+	return !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// Get decodes the field into dest.
+func (u UserFields) Get(name string, dest any) error {
+	raw, ok := u[userFieldName(name)]
+	if !ok {
+		return fmt.Errorf("user field %s is not set", userFieldName(name))
+	}
+
+	if err := json.Unmarshal(raw, dest); err != nil {
+		return fmt.Errorf("could not read user field %s due to %s", userFieldName(name), err)
+	}
+
+	return nil
+}
+
+// String returns the field as a string. ok is false when the field is absent, null or not a
+// string.
+func (u UserFields) String(name string) (string, bool) {
+	var v string
+	if u.IsNull(name) || u.Get(name, &v) != nil {
+		return "", false
+	}
+
+	return v, true
+}
+
+// Float returns the field as a float64. ok is false when the field is absent, null or not a
+// number.
+func (u UserFields) Float(name string) (float64, bool) {
+	var v float64
+	if u.IsNull(name) || u.Get(name, &v) != nil {
+		return 0, false
+	}
+
+	return v, true
+}
+
+// Decimal returns the field as an exact Decimal. ok is false when the field is absent, null or
+// not a number.
+func (u UserFields) Decimal(name string) (Decimal, bool) {
+	var v Decimal
+	if u.IsNull(name) || u.Get(name, &v) != nil {
+		return Zero, false
+	}
+
+	return v, true
+}
+
+// Int returns the field as an int. ok is false when the field is absent, null or not an
+// integer.
+func (u UserFields) Int(name string) (int, bool) {
+	var v int
+	if u.IsNull(name) || u.Get(name, &v) != nil {
+		return 0, false
+	}
+
+	return v, true
+}
+
+// Set stores the JSON encoding of value in the field.
+func (u *UserFields) Set(name string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("could not write user field %s due to %s", userFieldName(name), err)
+	}
+
+	if *u == nil {
+		*u = UserFields{}
+	}
+
+	(*u)[userFieldName(name)] = raw
+
+	return nil
+}
+
+// SetNull stores an explicit null in the field, clearing it in SAP when sent.
+func (u *UserFields) SetNull(name string) {
+	if *u == nil {
+		*u = UserFields{}
+	}
+
+	(*u)[userFieldName(name)] = json.RawMessage("null")
+}
+
+// Delete removes the field so it is not sent at all.
+func (u UserFields) Delete(name string) {
+	delete(u, userFieldName(name))
+}
+
+// unmarshalUserFields decodes data into v, an alias of an entity type without its JSON
+// methods, then stores the unmapped U_ properties of every (nested) struct in its UserFields.
+func unmarshalUserFields(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	return extractUserFields(data, reflect.ValueOf(v).Elem(), true)
+}
+
+// marshalUserFields encodes v, an alias of an entity type without its JSON methods, adding the
+// UserFields of every (nested) struct to its JSON object.
+func marshalUserFields(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return injectUserFields(data, reflect.ValueOf(v), true)
+}
+
+var (
+	userFieldsType  = reflect.TypeOf(UserFields{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// jsonFields maps the JSON names of the fields of struct value rv, including promoted ones,
+// to their values. The UserFields field, if any, is returned separately.
+func jsonFields(rv reflect.Value) (map[string]reflect.Value, reflect.Value) {
+	fields := map[string]reflect.Value{}
+
+	var udfs reflect.Value
+
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Type == userFieldsType {
+			udfs = rv.Field(i)
+
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded, embeddedUDFs := jsonFields(rv.Field(i))
+			for k, v := range embedded {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+
+			if !udfs.IsValid() {
+				udfs = embeddedUDFs
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = rv.Field(i)
+	}
+
+	return fields, udfs
+}
+
+// objectMember is a member of a JSON object, kept in document order.
+type objectMember struct {
+	Key   string
+	Value json.RawMessage
+}
+
+func decodeObject(data []byte) ([]objectMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var members []objectMember
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		members = append(members, objectMember{Key: key, Value: value})
+	}
+
+	return members, nil
+}
+
+func encodeObject(members []objectMember) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, member := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(member.Key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(member.Value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes()
+}
+
+// walkable tells whether the user fields of values of t are handled by extract/inject rather
+// than by the JSON methods of t itself. root is true for the entity being (un)marshalled.
+func walkable(t reflect.Type, root bool, methods reflect.Type) bool {
+	if !root && (t.Implements(methods) || reflect.PointerTo(t).Implements(methods)) {
+		return false
+	}
+
+	return true
+}
+
+func extractUserFields(data []byte, rv reflect.Value, root bool) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+
+		return extractUserFields(data, rv.Elem(), root)
+	case reflect.Slice:
+		if data[0] != '[' {
+			return nil
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+
+		for i := 0; i < len(items) && i < rv.Len(); i++ {
+			if err := extractUserFields(items[i], rv.Index(i), false); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if data[0] != '{' || !walkable(rv.Type(), root, unmarshalerType) {
+			return nil
+		}
+
+		members, err := decodeObject(data)
+		if err != nil {
+			return err
+		}
+
+		fields, udfs := jsonFields(rv)
+
+		for _, member := range members {
+			if field, ok := fields[member.Key]; ok {
+				if err := extractUserFields(member.Value, field, false); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if udfs.IsValid() && strings.HasPrefix(member.Key, UserFieldPrefix) {
+				if udfs.IsNil() {
+					udfs.Set(reflect.ValueOf(UserFields{}))
+				}
+
+				udfs.SetMapIndex(reflect.ValueOf(member.Key), reflect.ValueOf(member.Value))
+			}
+		}
+	}
+
+	return nil
+}
+
+func injectUserFields(data []byte, rv reflect.Value, root bool) ([]byte, error) {
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return data, nil
+		}
+
+		return injectUserFields(data, rv.Elem(), root)
+	case reflect.Slice:
+		if rv.IsNil() || rv.Type().Elem().Kind() == reflect.Uint8 {
+			return data, nil
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(items) && i < rv.Len(); i++ {
+			item, err := injectUserFields(items[i], rv.Index(i), false)
+			if err != nil {
+				return nil, err
+			}
+
+			items[i] = item
+		}
+
+		return json.Marshal(items)
+	case reflect.Struct:
+		if !walkable(rv.Type(), root, marshalerType) {
+			return data, nil
+		}
+
+		members, err := decodeObject(data)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, udfs := jsonFields(rv)
+
+		present := map[string]bool{}
+		for i, member := range members {
+			present[member.Key] = true
+
+			if field, ok := fields[member.Key]; ok {
+				value, err := injectUserFields(member.Value, field, false)
+				if err != nil {
+					return nil, err
+				}
+
+				members[i].Value = value
+			}
+		}
+
+		if udfs.IsValid() {
+			keys := make([]string, 0, udfs.Len())
+			for _, key := range udfs.MapKeys() {
+				if !present[key.String()] {
+					keys = append(keys, key.String())
+				}
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				value := udfs.MapIndex(reflect.ValueOf(key)).Interface().(json.RawMessage) //nolint:forcetypeassert
+				members = append(members, objectMember{Key: key, Value: value})
+			}
+		}
+
+		return encodeObject(members), nil
+	}
+
+	return data, nil
+}
+
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type item Item
+
+	return unmarshalUserFields(data, (*item)(i))
+}
+
+func (i Item) MarshalJSON() ([]byte, error) {
+	type item Item
+
+	return marshalUserFields(item(i))
+}
+
+func (bp *BusinessPartner) UnmarshalJSON(data []byte) error {
+	type businessPartner BusinessPartner
+
+	return unmarshalUserFields(data, (*businessPartner)(bp))
+}
+
+func (bp BusinessPartner) MarshalJSON() ([]byte, error) {
+	type businessPartner BusinessPartner
+
+	return marshalUserFields(businessPartner(bp))
+}
+
+func (d *Document) UnmarshalJSON(data []byte) error {
+	type document Document
+
+	return unmarshalUserFields(data, (*document)(d))
+}
+
+func (d Document) MarshalJSON() ([]byte, error) {
+	type document Document
+
+	return marshalUserFields(document(d))
+}
+
+func (d *DeliveryNote) UnmarshalJSON(data []byte) error {
+	type deliveryNote DeliveryNote
+
+	return unmarshalUserFields(data, (*deliveryNote)(d))
+}
+
+func (d DeliveryNote) MarshalJSON() ([]byte, error) {
+	type deliveryNote DeliveryNote
+
+	return marshalUserFields(deliveryNote(d))
+}
+
+func (d *PurchaseOrder) UnmarshalJSON(data []byte) error {
+	type purchaseOrder PurchaseOrder
+
+	return unmarshalUserFields(data, (*purchaseOrder)(d))
+}
+
+func (d PurchaseOrder) MarshalJSON() ([]byte, error) {
+	type purchaseOrder PurchaseOrder
+
+	return marshalUserFields(purchaseOrder(d))
+}
+
+func (d *PurchaseDeliveryNote) UnmarshalJSON(data []byte) error {
+	type purchaseDeliveryNote PurchaseDeliveryNote
+
+	return unmarshalUserFields(data, (*purchaseDeliveryNote)(d))
+}
+
+func (d PurchaseDeliveryNote) MarshalJSON() ([]byte, error) {
+	type purchaseDeliveryNote PurchaseDeliveryNote
+
+	return marshalUserFields(purchaseDeliveryNote(d))
+}
+
+func (d *Draft) UnmarshalJSON(data []byte) error {
+	type draft Draft
+
+	return unmarshalUserFields(data, (*draft)(d))
+}
+
+func (d Draft) MarshalJSON() ([]byte, error) {
+	type draft Draft
+
+	return marshalUserFields(draft(d))
+}
+
+func (c *InventoryCounting) UnmarshalJSON(data []byte) error {
+	type inventoryCounting InventoryCounting
+
+	return unmarshalUserFields(data, (*inventoryCounting)(c))
+}
+
+func (c InventoryCounting) MarshalJSON() ([]byte, error) {
+	type inventoryCounting InventoryCounting
+
+	return marshalUserFields(inventoryCounting(c))
+}
+
+func (l *BinLocation) UnmarshalJSON(data []byte) error {
+	type binLocation BinLocation
+
+	return unmarshalUserFields(data, (*binLocation)(l))
+}
+
+func (l BinLocation) MarshalJSON() ([]byte, error) {
+	type binLocation BinLocation
+
+	return marshalUserFields(binLocation(l))
+}
+
+func (l *PriceList) UnmarshalJSON(data []byte) error {
+	type priceList PriceList
+
+	return unmarshalUserFields(data, (*priceList)(l))
+}
+
+func (l PriceList) MarshalJSON() ([]byte, error) {
+	type priceList PriceList
+
+	return marshalUserFields(priceList(l))
+}
+
+func (p *SpecialPrice) UnmarshalJSON(data []byte) error {
+	type specialPrice SpecialPrice
+
+	return unmarshalUserFields(data, (*specialPrice)(p))
+}
+
+func (p SpecialPrice) MarshalJSON() ([]byte, error) {
+	type specialPrice SpecialPrice
+
+	return marshalUserFields(specialPrice(p))
+}
+
+func (r *UserTableRow) UnmarshalJSON(data []byte) error {
+	type userTableRow UserTableRow
+
+	return unmarshalUserFields(data, (*userTableRow)(r))
+}
+
+func (r UserTableRow) MarshalJSON() ([]byte, error) {
+	type userTableRow UserTableRow
+
+	return marshalUserFields(userTableRow(r))
+}
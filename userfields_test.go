@@ -0,0 +1,71 @@
+package gosap_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/octomiro/gosap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserFieldsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	payload := `{"DocEntry":7,"CardCode":"C20000","U_PlateNum":"123TU4567","U_Driver":"Sami",` +
+		`"U_Weight":12.50,"U_Checked":null,"DocumentLines":[{"LineNum":0,"ItemCode":"A00001",` +
+		`"U_SelectedQuantity":2,"U_Lot":"L-1"}]}`
+
+	var note gosap.DeliveryNote
+	require.NoError(t, json.Unmarshal([]byte(payload), &note))
+
+	assert.Equal(t, "123TU4567", note.PlateNum)
+	assert.False(t, note.UserFields.Has("PlateNum"))
+
+	driver, ok := note.UserFields.String("Driver")
+	assert.True(t, ok)
+	assert.Equal(t, "Sami", driver)
+
+	weight, ok := note.UserFields.Float("U_Weight")
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, weight)
+
+	exact, ok := note.UserFields.Decimal("Weight")
+	assert.True(t, ok)
+	assert.Equal(t, gosap.Decimal("12.5"), exact)
+
+	assert.True(t, note.UserFields.Has("Checked"))
+	assert.True(t, note.UserFields.IsNull("Checked"))
+
+	require.Len(t, note.DocumentLines, 1)
+	assert.Equal(t, gosap.Decimal("2"), note.DocumentLines[0].SelectedQuantity)
+
+	lot, ok := note.DocumentLines[0].UserFields.String("Lot")
+	assert.True(t, ok)
+	assert.Equal(t, "L-1", lot)
+
+	out, err := json.Marshal(note)
+	require.NoError(t, err)
+
+	var want, got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(payload), &want))
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, want, got)
+	assert.Contains(t, string(out), `"U_Weight":12.50`)
+}
+
+func TestUserFieldsSet(t *testing.T) {
+	t.Parallel()
+
+	partner := gosap.BusinessPartner{CardCode: "C1"}
+	require.NoError(t, partner.UserFields.Set("Segment", "retail"))
+	partner.UserFields.SetNull("U_Region")
+	partner.BPAddresses = []gosap.BPAddress{{AddressName: "HQ"}}
+	require.NoError(t, partner.BPAddresses[0].UserFields.Set("Gate", 3))
+
+	out, err := json.Marshal(partner)
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"CardCode":"C1","BPAddresses":[{"AddressName":"HQ","U_Gate":3}],"U_Region":null,"U_Segment":"retail"}`,
+		string(out))
+}
@@ -0,0 +1,281 @@
+package gosap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// UserSchema is a set of user tables, fields and objects an integration relies on.
+type UserSchema struct {
+	Tables  []UserTable
+	Fields  []UserField
+	Objects []UserObject
+}
+
+// UserSchemaChanges lists what EnsureUserSchema had to create.
+type UserSchemaChanges struct {
+	Tables  []string
+	Fields  []string
+	Objects []string
+}
+
+// EnsureUserSchema creates the tables, fields and objects of schema that don't exist yet, in
+// that order so fields and objects can refer to new tables. Existing definitions are left as
+// they are, which makes it safe to run on every start.
+func (s *Session) EnsureUserSchema(cfg Config, schema UserSchema) (_ *UserSchemaChanges, err error) {
+	s, span := s.startOperation()
+	defer span.finish(&err)
+
+	changes := &UserSchemaChanges{}
+
+	tables, err := retrieveDocuments[UserTable](s, cfg, cfg.GetUserTablesEndpoint()+"?$select=TableName")
+	if err != nil {
+		return changes, fmt.Errorf("could not fetch user tables due to %s", err)
+	}
+
+	existingTables := map[string]bool{}
+	for _, table := range tables {
+		existingTables[table.TableName] = true
+	}
+
+	for _, table := range schema.Tables {
+		table.TableName = userTableName(table.TableName)
+		if existingTables[table.TableName] {
+			continue
+		}
+
+		if _, err := s.CreateUserTable(cfg, table); err != nil {
+			return changes, err
+		}
+
+		changes.Tables = append(changes.Tables, table.TableName)
+	}
+
+	existingFields := map[string]map[string]bool{}
+	for _, field := range schema.Fields {
+		if _, ok := existingFields[field.TableName]; !ok {
+			fields, err := s.GetTableUserFields(cfg, field.TableName)
+			if err != nil {
+				return changes, fmt.Errorf("could not fetch user fields of %s due to %s", field.TableName, err)
+			}
+
+			existingFields[field.TableName] = map[string]bool{}
+			for _, existing := range fields {
+				existingFields[field.TableName][existing.Name] = true
+			}
+		}
+
+		if existingFields[field.TableName][field.Name] {
+			continue
+		}
+
+		if _, err := s.CreateUserField(cfg, field); err != nil {
+			return changes, err
+		}
+
+		existingFields[field.TableName][field.Name] = true
+		changes.Fields = append(changes.Fields, field.TableName+"."+UserFieldPrefix+field.Name)
+	}
+
+	if len(schema.Objects) == 0 {
+		return changes, nil
+	}
+
+	objects, err := retrieveDocuments[UserObject](s, cfg, cfg.GetUserObjectsEndpoint()+"?$select=Code")
+	if err != nil {
+		return changes, fmt.Errorf("could not fetch user objects due to %s", err)
+	}
+
+	existingObjects := map[string]bool{}
+	for _, object := range objects {
+		existingObjects[object.Code] = true
+	}
+
+	for _, object := range schema.Objects {
+		if existingObjects[object.Code] {
+			continue
+		}
+
+		if _, err := s.CreateUserObject(cfg, object); err != nil {
+			return changes, err
+		}
+
+		changes.Objects = append(changes.Objects, object.Code)
+	}
+
+	return changes, nil
+}
+
+func (s *Session) GetUserTables(cfg Config) ([]UserTable, error) {
+	return retrieveDocuments[UserTable](s, cfg, cfg.GetUserTablesEndpoint())
+}
+
+func (s *Session) CreateUserTable(cfg Config, table UserTable) (*UserTable, error) {
+	table.TableName = userTableName(table.TableName)
+
+	created, err := createDocument[UserTable](s, cfg.GetUserTablesEndpoint(), table)
+	if err != nil {
+		return nil, fmt.Errorf("could not create user table %s due to %w", table.TableName, err)
+	}
+
+	return created, nil
+}
+
+func (s *Session) DeleteUserTable(cfg Config, table string) error {
+	return s.deleteEntity(cfg.GetUserTableEndpoint(table), "user table "+table)
+}
+
+// GetTableUserFields fetches the user-defined fields of a system table (OCRD, ...) or of a user
+// table (@NAME).
+func (s *Session) GetTableUserFields(cfg Config, table string) ([]UserField, error) {
+	return retrieveDocuments[UserField](s, cfg, cfg.GetTableUserFieldsEndpoint(table))
+}
+
+func (s *Session) CreateUserField(cfg Config, field UserField) (*UserField, error) {
+	created, err := createDocument[UserField](s, cfg.GetUserFieldsEndpoint(), field)
+	if err != nil {
+		return nil, fmt.Errorf("could not create user field %s on %s due to %w", field.Name, field.TableName, err)
+	}
+
+	return created, nil
+}
+
+// UpdateUserField sends the non-empty fields of updates to the field definition.
+func (s *Session) UpdateUserField(cfg Config, table string, fieldID int, updates UserField) error {
+	if err := updateDocument(s, cfg.GetUserFieldEndpoint(table, fieldID), updates); err != nil {
+		return fmt.Errorf("could not update user field %d on %s due to %w", fieldID, table, err)
+	}
+
+	return nil
+}
+
+func (s *Session) DeleteUserField(cfg Config, table string, fieldID int) error {
+	return s.deleteEntity(cfg.GetUserFieldEndpoint(table, fieldID), fmt.Sprintf("user field %d on %s", fieldID, table))
+}
+
+func (s *Session) GetUserObjects(cfg Config) ([]UserObject, error) {
+	return retrieveDocuments[UserObject](s, cfg, cfg.GetUserObjectsEndpoint())
+}
+
+func (s *Session) CreateUserObject(cfg Config, object UserObject) (*UserObject, error) {
+	created, err := createDocument[UserObject](s, cfg.GetUserObjectsEndpoint(), object)
+	if err != nil {
+		return nil, fmt.Errorf("could not create user object %s due to %w", object.Code, err)
+	}
+
+	return created, nil
+}
+
+func (s *Session) DeleteUserObject(cfg Config, code string) error {
+	return s.deleteEntity(cfg.GetUserObjectEndpoint(code), "user object "+code)
+}
+
+func (s *Session) GetUserTableRows(cfg Config, table string) ([]UserTableRow, error) {
+	return retrieveDocuments[UserTableRow](s, cfg, cfg.GetUserTableRowsEndpoint(table))
+}
+
+func (s *Session) GetUserTableRow(cfg Config, table, code string) (*UserTableRow, error) {
+	return retrieveDocument[UserTableRow](s, cfg.GetUserTableRowEndpoint(table, code))
+}
+
+func (s *Session) CreateUserTableRow(cfg Config, table string, row UserTableRow) (*UserTableRow, error) {
+	created, err := createDocument[UserTableRow](s, cfg.GetUserTableRowsEndpoint(table), row)
+	if err != nil {
+		return nil, fmt.Errorf("could not create row in %s due to %s", table, err)
+	}
+
+	return created, nil
+}
+
+// UpdateUserTableRow sends Name, if set, and the user fields of updates to the row.
+func (s *Session) UpdateUserTableRow(cfg Config, table, code string, updates UserTableRow) error {
+	updates.Code = ""
+
+	if err := updateDocument(s, cfg.GetUserTableRowEndpoint(table, code), updates); err != nil {
+		return fmt.Errorf("could not update row %s of %s due to %w", code, table, err)
+	}
+
+	return nil
+}
+
+func (s *Session) DeleteUserTableRow(cfg Config, table, code string) error {
+	return s.deleteEntity(cfg.GetUserTableRowEndpoint(table, code), fmt.Sprintf("row %s of %s", code, table))
+}
+
+func (s *Session) GetUserObjectRecords(cfg Config, udo string) ([]UserObjectRecord, error) {
+	return retrieveDocuments[UserObjectRecord](s, cfg, cfg.GetUserObjectRecordsEndpoint(udo))
+}
+
+// GetUserObjectRecord fetches a record of a user-defined object by its Code (master data) or
+// DocEntry (document).
+func (s *Session) GetUserObjectRecord(cfg Config, udo string, key any) (UserObjectRecord, error) {
+	record, err := retrieveDocument[UserObjectRecord](s, cfg.GetUserObjectRecordEndpoint(udo, key))
+	if err != nil {
+		return nil, err
+	}
+
+	return *record, nil
+}
+
+func (s *Session) CreateUserObjectRecord(cfg Config, udo string, record UserObjectRecord) (UserObjectRecord, error) {
+	created, err := createDocument[UserObjectRecord](s, cfg.GetUserObjectRecordsEndpoint(udo), record)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s record due to %s", udo, err)
+	}
+
+	return *created, nil
+}
+
+func (s *Session) UpdateUserObjectRecord(cfg Config, udo string, key any, updates UserObjectRecord) error {
+	if err := updateDocument(s, cfg.GetUserObjectRecordEndpoint(udo, key), updates); err != nil {
+		return fmt.Errorf("could not update %s record %v due to %w", udo, key, err)
+	}
+
+	return nil
+}
+
+func (s *Session) DeleteUserObjectRecord(cfg Config, udo string, key any) error {
+	return s.deleteEntity(cfg.GetUserObjectRecordEndpoint(udo, key), fmt.Sprintf("%s record %v", udo, key))
+}
+
+// deleteEntity deletes the entity at endpoint. what describes it in errors.
+func (s *Session) deleteEntity(endpoint, what string) error {
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("could not create delete request due to %w", err)
+	}
+
+	_, _, err = s.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete %s due to %w", what, err)
+	}
+
+	return nil
+}
+
+// Get decodes the property into dest.
+func (r UserObjectRecord) Get(name string, dest any) error {
+	raw, ok := r[name]
+	if !ok {
+		return fmt.Errorf("property %s is not set", name)
+	}
+
+	if err := json.Unmarshal(raw, dest); err != nil {
+		return fmt.Errorf("could not read property %s due to %s", name, err)
+	}
+
+	return nil
+}
+
+// Set stores the JSON encoding of value in the property.
+func (r UserObjectRecord) Set(name string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("could not write property %s due to %s", name, err)
+	}
+
+	r[name] = raw
+
+	return nil
+}
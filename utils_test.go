@@ -1,9 +1,21 @@
 package gosap_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
 
 	"github.com/octomiro/gosap"
+	"github.com/octomiro/gosap/gosaptest"
+	"github.com/stretchr/testify/require"
 )
 
 func ItemsToJSON(items gosap.Items) string {
@@ -38,3 +50,107 @@ func ToJSON[T any](t T) string {
 func FromJSON(content string, toMarshal any) error {
 	return json.Unmarshal([]byte(content), &toMarshal)
 }
+
+// serviceLayerStub serves handler over TLS and returns the config pointing at it.
+func serviceLayerStub(t *testing.T, handler http.HandlerFunc) gosap.Config {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+
+	return gosap.Config{IP: host, Port: uint16(portNum)}
+}
+
+// cassetteConfig returns config sending the requests of the test through its cassette in
+// testdata/cassettes: recorded from the Service Layer of gosap.env with -record, replayed
+// otherwise. The committed cassettes serve the .golden files back, so replaying them only checks
+// that decoding and encoding round-trip the goldens, not how a live Service Layer behaves. Tests
+// without a cassette are skipped rather than sent to the network.
+func cassetteConfig(t *testing.T) gosap.Config {
+	t.Helper()
+
+	path := filepath.Join("testdata", "cassettes", filepath.FromSlash(t.Name())+".json")
+
+	mode := gosaptest.Replay
+	if *record {
+		mode = gosaptest.Record
+	} else if _, err := os.Stat(path); err != nil {
+		t.Skipf("no cassette at %s, record one with -record", path)
+	}
+
+	rec, err := gosaptest.NewRecorder(path, mode)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, rec.Save()) })
+
+	return rec.Config(config)
+}
+
+// sentRequest is a request sent to the Service Layer by a test, with its JSON body decoded.
+type sentRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   map[string]any
+}
+
+// captureRequests adds a middleware to cfg recording the requests sent with it, returned by the
+// function.
+func captureRequests(t *testing.T, cfg *gosap.Config) func() []sentRequest {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		sent []sentRequest
+	)
+
+	cfg.Middleware = append(cfg.Middleware, func(next http.RoundTripper) http.RoundTripper {
+		return gosap.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			r := sentRequest{Method: req.Method, Path: req.URL.Path, Header: req.Header.Clone()}
+
+			if req.Body != nil {
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+
+				req.Body = io.NopCloser(bytes.NewReader(body))
+
+				if len(body) > 0 {
+					_ = json.Unmarshal(body, &r.Body)
+				}
+			}
+
+			mu.Lock()
+			sent = append(sent, r)
+			mu.Unlock()
+
+			return next.RoundTrip(req)
+		})
+	})
+
+	return func() []sentRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]sentRequest(nil), sent...)
+	}
+}
+
+// lastRequest returns the last request sent with method.
+func lastRequest(t *testing.T, sent []sentRequest, method string) sentRequest {
+	t.Helper()
+
+	for i := len(sent) - 1; i >= 0; i-- {
+		if sent[i].Method == method {
+			return sent[i]
+		}
+	}
+
+	require.FailNow(t, "no request sent with "+method)
+
+	return sentRequest{}
+}